@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const AdminAPIAddrMotd = "http://localhost:7667"
+
+var motdCmd = &cobra.Command{
+	Use:   "motd",
+	Short: "Message of the day management",
+}
+
+var motdGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show the message of the day",
+	Run: func(cmd *cobra.Command, args []string) {
+		resp, err := http.Get(fmt.Sprintf("%s/motd/get", AdminAPIAddrMotd))
+		if err != nil {
+			fmt.Printf("Error getting MOTD: %v\n", err)
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		fmt.Print(string(body))
+	},
+}
+
+var motdFile string
+var motdSetCmd = &cobra.Command{
+	Use:   "set [line...]",
+	Short: "Replace the message of the day, one argument per line ({name}, {online}, {time} and {date} are replaced on login)",
+	Run: func(cmd *cobra.Command, args []string) {
+		text := strings.Join(args, "\n")
+		if motdFile != "" {
+			data, err := os.ReadFile(motdFile)
+			if err != nil {
+				fmt.Printf("Error reading %s: %v\n", motdFile, err)
+				return
+			}
+			text = string(data)
+		}
+
+		resp, err := http.PostForm(fmt.Sprintf("%s/motd/set", AdminAPIAddrMotd), url.Values{"text": {text}})
+		if err != nil {
+			fmt.Printf("Error setting MOTD: %v\n", err)
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		fmt.Println(string(body))
+	},
+}
+
+func init() {
+	motdSetCmd.Flags().StringVarP(&motdFile, "file", "f", "", "Read the message from a text file")
+
+	motdCmd.AddCommand(motdGetCmd)
+	motdCmd.AddCommand(motdSetCmd)
+	rootCmd.AddCommand(motdCmd)
+}
@@ -97,6 +97,21 @@ var npcListCmd = &cobra.Command{
 	},
 }
 
+var npcRespawnsCmd = &cobra.Command{
+	Use:   "respawns",
+	Short: "List pending NPC respawns",
+	Run: func(cmd *cobra.Command, args []string) {
+		resp, err := http.Get(fmt.Sprintf("%s/npc/respawns", AdminAPIAddrNPC))
+		if err != nil {
+			fmt.Printf("Error listing respawns: %v\n", err)
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		fmt.Println(string(body))
+	},
+}
+
 func init() {
 	npcRespawnCmd.Flags().StringVarP(&respawnMap, "map", "m", "", "Map ID")
 
@@ -105,5 +120,6 @@ func init() {
 	npcCmd.AddCommand(npcEnableCmd)
 	npcCmd.AddCommand(npcRespawnCmd)
 	npcCmd.AddCommand(npcListCmd)
+	npcCmd.AddCommand(npcRespawnsCmd)
 	rootCmd.AddCommand(npcCmd)
 }
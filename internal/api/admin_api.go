@@ -29,6 +29,8 @@ type AdminAPI struct {
 	messageService service.MessageService
 	npcService     service.NpcService
 	aiService      service.AiService
+	sosService     service.SosService
+	motdService    service.MotdService
 	config         *config.Config
 	globalBalance  *model.GlobalBalanceConfig
 	configPath     string
@@ -43,7 +45,7 @@ type AdminAPI struct {
 	classDistribution map[string]int
 }
 
-func NewAdminAPI(mapService service.MapService, userService service.UserService, userRepo persistence.UserRepository, loginService service.LoginService, messageService service.MessageService, npcService service.NpcService, aiService service.AiService, cfg *config.Config, globalBalance *model.GlobalBalanceConfig, configPath string) *AdminAPI {
+func NewAdminAPI(mapService service.MapService, userService service.UserService, userRepo persistence.UserRepository, loginService service.LoginService, messageService service.MessageService, npcService service.NpcService, aiService service.AiService, sosService service.SosService, motdService service.MotdService, cfg *config.Config, globalBalance *model.GlobalBalanceConfig, configPath string) *AdminAPI {
 	loc, err := time.LoadLocation("America/Argentina/Buenos_Aires")
 	if err != nil {
 		// Fallback to FixedZone if TZ data is not available
@@ -58,6 +60,8 @@ func NewAdminAPI(mapService service.MapService, userService service.UserService,
 		messageService: messageService,
 		npcService:     npcService,
 		aiService:      aiService,
+		sosService:     sosService,
+		motdService:    motdService,
 		config:         cfg,
 		globalBalance:  globalBalance,
 		configPath:     configPath,
@@ -185,6 +189,13 @@ func (a *AdminAPI) Start(addr string) error {
 	mux.HandleFunc("/npc/enable", a.handleNpcEnable)
 	mux.HandleFunc("/npc/respawn", a.handleNpcRespawn)
 	mux.HandleFunc("/npc/list", a.handleNpcList)
+	mux.HandleFunc("/npc/respawns", a.handleNpcRespawns)
+
+	mux.HandleFunc("/sos/list", a.handleSosList)
+	mux.HandleFunc("/sos/close", a.handleSosClose)
+
+	mux.HandleFunc("/motd/get", a.handleMotdGet)
+	mux.HandleFunc("/motd/set", a.handleMotdSet)
 
 	mux.HandleFunc("/config/get", a.handleConfigGet)
 	mux.HandleFunc("/config/set", a.handleConfigSet)
@@ -438,7 +449,7 @@ func (a *AdminAPI) handleNpcList(w http.ResponseWriter, r *http.Request) {
 	npcs := a.npcService.GetWorldNpcs()
 	list := make([]map[string]interface{}, 0, len(npcs))
 	for _, npc := range npcs {
-		list = append(list, map[string]interface{}{
+		entry := map[string]interface{}{
 			"index": npc.Index,
 			"id":    npc.NPC.ID,
 			"name":  npc.NPC.Name,
@@ -446,11 +457,101 @@ func (a *AdminAPI) handleNpcList(w http.ResponseWriter, r *http.Request) {
 			"x":     npc.Position.X,
 			"y":     npc.Position.Y,
 			"hp":    npc.HP,
+		}
+		if npc.SpawnedBy != "" {
+			entry["spawned_by"] = npc.SpawnedBy
+		}
+		list = append(list, entry)
+	}
+	json.NewEncoder(w).Encode(list)
+}
+
+func (a *AdminAPI) handleNpcRespawns(w http.ResponseWriter, r *http.Request) {
+	pending := a.npcService.GetPendingRespawns()
+	sort.Slice(pending, func(i, j int) bool { return pending[i].DueAt.Before(pending[j].DueAt) })
+
+	list := make([]map[string]interface{}, 0, len(pending))
+	for _, p := range pending {
+		entry := map[string]interface{}{
+			"id":         p.NpcID,
+			"map":        p.Map,
+			"in_seconds": max(0, int(time.Until(p.DueAt).Seconds())),
+		}
+		if def := a.npcService.GetNpcDef(p.NpcID); def != nil {
+			entry["name"] = def.Name
+		}
+		if p.Origin != nil {
+			entry["x"] = p.Origin.X
+			entry["y"] = p.Origin.Y
+		}
+		if p.Region != nil {
+			entry["region"] = p.Region.Name
+		}
+		list = append(list, entry)
+	}
+	json.NewEncoder(w).Encode(list)
+}
+
+func (a *AdminAPI) handleSosList(w http.ResponseWriter, r *http.Request) {
+	tickets := a.sosService.GetOpenTickets()
+
+	list := make([]map[string]interface{}, 0, len(tickets))
+	for _, t := range tickets {
+		list = append(list, map[string]interface{}{
+			"id":         t.ID,
+			"author":     t.Author,
+			"message":    t.Message,
+			"map":        t.Position.Map,
+			"x":          t.Position.X,
+			"y":          t.Position.Y,
+			"created_at": t.CreatedAt,
+			"claimed_by": t.ClaimedBy,
+			"online":     a.userService.IsUserLoggedIn(t.Author),
 		})
 	}
 	json.NewEncoder(w).Encode(list)
 }
 
+func (a *AdminAPI) handleSosClose(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := r.FormValue("id")
+	if id == "" {
+		http.Error(w, "Missing id", http.StatusBadRequest)
+		return
+	}
+
+	if !a.sosService.Close("admin", id) {
+		http.Error(w, "Ticket not found", http.StatusNotFound)
+		return
+	}
+	fmt.Fprintf(w, "Ticket %s closed", id)
+}
+
+func (a *AdminAPI) handleMotdGet(w http.ResponseWriter, r *http.Request) {
+	for _, line := range a.motdService.GetMotd() {
+		fmt.Fprintln(w, line)
+	}
+}
+
+func (a *AdminAPI) handleMotdSet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := a.motdService.SetMotd(r.FormValue("text")); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprintf(w, "MOTD updated (%d lines)", len(a.motdService.GetMotd()))
+}
+
 func (a *AdminAPI) handleNpcReload(w http.ResponseWriter, r *http.Request) {
 	if err := a.npcService.LoadNpcs(); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -598,10 +699,30 @@ func (a *AdminAPI) handlePlayerInfo(w http.ResponseWriter, r *http.Request) {
 		"x":         char.Position.X,
 		"y":         char.Position.Y,
 		"archetype": char.Archetype,
+		"effects":   effectsInfo(char),
 	}
 	json.NewEncoder(w).Encode(info)
 }
 
+// effectsInfo lists the effects running on a character; remaining_ms is 0 for effects that last
+// until dispelled.
+func effectsInfo(char *model.Character) []map[string]interface{} {
+	active := char.ActiveEffects()
+	effects := make([]map[string]interface{}, 0, len(active))
+	for _, effect := range active {
+		remaining := int64(0)
+		if !effect.Expires.IsZero() {
+			remaining = max(0, time.Until(effect.Expires).Milliseconds())
+		}
+		effects = append(effects, map[string]interface{}{
+			"type":         effect.Type.Name(),
+			"magnitude":    effect.Magnitude,
+			"remaining_ms": remaining,
+		})
+	}
+	return effects
+}
+
 func (a *AdminAPI) handlePlayerSave(w http.ResponseWriter, r *http.Request) {
 	nick := r.URL.Query().Get("nick")
 	all := r.URL.Query().Get("all") == "true"
@@ -23,6 +23,10 @@ type ProjectConfig struct {
 			CitiesDat   string `yaml:"cities_dat"`
 			NpcsDat     string `yaml:"npcs_dat"`
 			ObjectsDat  string `yaml:"objects_dat"`
+			QuestsDat   string `yaml:"quests_dat"`
+			SosDat      string `yaml:"sos_dat"`
+			MotdDat     string `yaml:"motd_dat"`
+			BanIPsDat   string `yaml:"banips_dat"`
 			Maps        string `yaml:"maps"`
 		} `yaml:"paths"`
 		MapsCount   int `yaml:"maps_count"`
@@ -68,3 +68,37 @@ const (
 	Wrestling
 	Sailing
 )
+
+// MaxSkillPoints is the highest value a skill can reach.
+const MaxSkillPoints = 100
+
+// MinAttributeValue is the lowest value spells can lower an attribute to.
+const MinAttributeValue = 6
+
+var skillNames = map[Skill]string{
+	Magic:       "Magia",
+	Steal:       "Robar",
+	Evasion:     "Evasión en combate",
+	MeleeCombat: "Combate con armas",
+	Meditate:    "Meditar",
+	Stab:        "Apuñalar",
+	Hiding:      "Ocultarse",
+	Survive:     "Supervivencia",
+	Lumber:      "Talar",
+	Trade:       "Comercio",
+	Defense:     "Defensa con escudos",
+	Fishing:     "Pesca",
+	Mining:      "Minería",
+	Woodwork:    "Carpintería",
+	Ironwork:    "Herrería",
+	Leadership:  "Liderazgo",
+	Tame:        "Domar animales",
+	Projectiles: "Combate a distancia",
+	Wrestling:   "Combate sin armas",
+	Sailing:     "Navegación",
+}
+
+// Name returns the skill name shown to players.
+func (s Skill) Name() string {
+	return skillNames[s]
+}
@@ -0,0 +1,149 @@
+package model
+
+import "time"
+
+// EffectType identifies a timed status effect on a character.
+type EffectType int
+
+const (
+	EffectPoison EffectType = iota + 1
+	EffectParalysis
+	EffectImmobilization
+	EffectStun
+	EffectStrength
+	EffectAgility
+	EffectMimicry
+)
+
+// Poison hurts every PoisonInterval for 1 to the effect's Magnitude points.
+const (
+	PoisonInterval = 2 * time.Second
+	PoisonDamage   = 5 // Magnitude of the poison of spells and creatures
+)
+
+// MaxAttributeBonus is how far over its original value effects can raise an attribute.
+const MaxAttributeBonus = 20
+
+var effectNames = map[EffectType]string{
+	EffectPoison:         "Veneno",
+	EffectParalysis:      "Parálisis",
+	EffectImmobilization: "Inmovilización",
+	EffectStun:           "Aturdimiento",
+	EffectStrength:       "Fuerza",
+	EffectAgility:        "Agilidad",
+	EffectMimicry:        "Mimetismo",
+}
+
+// Name returns the display name of the effect.
+func (t EffectType) Name() string {
+	return effectNames[t]
+}
+
+// Attribute returns the attribute an effect changes, if any.
+func (t EffectType) Attribute() (Attribute, bool) {
+	switch t {
+	case EffectStrength:
+		return Strength, true
+	case EffectAgility:
+		return Dexterity, true
+	}
+	return 0, false
+}
+
+// Effect is a status effect running on a character.
+type Effect struct {
+	Type      EffectType
+	Magnitude int       // Points added to the attribute (negative for debuffs), most damage per poison tick
+	Expires   time.Time // Zero for effects that last until dispelled
+	NextTick  time.Time // Next poison damage
+}
+
+// HasEffect tells whether the character is under an effect of type t.
+func (c *Character) HasEffect(t EffectType) bool {
+	c.effectsMu.Lock()
+	defer c.effectsMu.Unlock()
+	_, ok := c.Effects[t]
+	return ok
+}
+
+// UpdateEffect calls update with the effect of type t, created if the character wasn't under it.
+// active tells which one was the case. update runs with the effects locked, it must not reach
+// back into them.
+func (c *Character) UpdateEffect(t EffectType, update func(effect *Effect, active bool)) {
+	c.effectsMu.Lock()
+	defer c.effectsMu.Unlock()
+
+	if c.Effects == nil {
+		c.Effects = make(map[EffectType]*Effect)
+	}
+	effect, active := c.Effects[t]
+	if !active {
+		effect = &Effect{Type: t}
+		c.Effects[t] = effect
+	}
+	update(effect, active)
+}
+
+// RemoveEffect drops the effect of type t, restoring the attribute it changed. It returns false
+// if the character wasn't under it.
+func (c *Character) RemoveEffect(t EffectType) bool {
+	c.effectsMu.Lock()
+	defer c.effectsMu.Unlock()
+
+	if _, ok := c.Effects[t]; !ok {
+		return false
+	}
+	delete(c.Effects, t)
+	if attr, ok := t.Attribute(); ok {
+		c.Attributes[attr] = c.OriginalAttributes[attr]
+	}
+	return true
+}
+
+// ActiveEffects returns a copy of the effects running on the character.
+func (c *Character) ActiveEffects() []Effect {
+	c.effectsMu.Lock()
+	defer c.effectsMu.Unlock()
+
+	effects := make([]Effect, 0, len(c.Effects))
+	for _, effect := range c.Effects {
+		effects = append(effects, *effect)
+	}
+	return effects
+}
+
+// DueEffects moves the poison on when its tick is due at now, returning its magnitude (0 when
+// no tick is due), and lists the effects expired by now.
+func (c *Character) DueEffects(now time.Time) (poison int, expired []EffectType) {
+	c.effectsMu.Lock()
+	defer c.effectsMu.Unlock()
+
+	for t, effect := range c.Effects {
+		if t == EffectPoison && !now.Before(effect.NextTick) {
+			effect.NextTick = now.Add(PoisonInterval)
+			poison = effect.Magnitude
+		}
+		if !effect.Expires.IsZero() && now.After(effect.Expires) {
+			expired = append(expired, t)
+		}
+	}
+	return poison, expired
+}
+
+// CanMove tells whether no effect keeps the character from walking.
+func (c *Character) CanMove() bool {
+	return !c.HasEffect(EffectParalysis) && !c.HasEffect(EffectImmobilization) && !c.HasEffect(EffectStun)
+}
+
+// ClearEffects drops every effect at once, restoring the attributes they changed.
+func (c *Character) ClearEffects() {
+	c.effectsMu.Lock()
+	defer c.effectsMu.Unlock()
+
+	for t := range c.Effects {
+		if attr, ok := t.Attribute(); ok {
+			c.Attributes[attr] = c.OriginalAttributes[attr]
+		}
+	}
+	c.Effects = make(map[EffectType]*Effect)
+}
@@ -0,0 +1,45 @@
+package model
+
+// HasLineOfSight walks the straight line between from and to and reports whether it is free of
+// obstacles. Blocked tiles stop the line, except water, which projectiles and spells fly over.
+// Both ends are ignored so the line can start and finish on occupied tiles.
+func (m *Map) HasLineOfSight(from, to Position) bool {
+	if from.Map != to.Map {
+		return false
+	}
+	if from == to {
+		return true
+	}
+
+	x, y := int(from.X), int(from.Y)
+	x1, y1 := int(to.X), int(to.Y)
+	dx, dy := absInt(x1-x), -absInt(y1-y)
+	sx, sy := 1, 1
+	if x > x1 {
+		sx = -1
+	}
+	if y > y1 {
+		sy = -1
+	}
+
+	err := dx + dy
+	for {
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y += sy
+		}
+		if x == x1 && y == y1 {
+			return true
+		}
+
+		tile := m.GetTile(x, y)
+		if tile.Blocked && !tile.IsWater {
+			return false
+		}
+	}
+}
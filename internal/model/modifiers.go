@@ -18,6 +18,9 @@ type RaceModifiers struct {
 	Intelligence int
 	Charisma     int
 	Constitution int
+
+	// Classes a character of this race can't be created with
+	ForbiddenArchetypes []UserArchetype
 }
 
 type GlobalBalanceConfig struct {
@@ -43,4 +46,61 @@ type GlobalBalanceConfig struct {
 	NPCIntervalMove   int64
 	NPCIntervalAttack int64
 	NPCParalizedTime  int64
+	NPCRespawnTime    int // seconds
+
+	// Gambling
+	GamblingHouseEdge float64
+	GamblingMaxBet    int
+	GamblingHourlyCap int
+
+	// Duels and arenas
+	DuelCountdown        int // seconds
+	DuelChallengeTimeout int // seconds
+	DuelMaxDuration      int // seconds, 0 for no limit
+	ArenaExit            Position
+
+	// Anti-picket and jail
+	AntiPicketWarnInterval int // seconds between warnings
+	AntiPicketLimit        int // seconds a player may stay on an anti-picket tile
+	AntiPicketJail         bool
+	AntiPicketJailTime     int // minutes
+	JailPosition           Position
+	JailExit               Position
+	JailRadius             int // tiles around JailPosition prisoners may walk
+
+	// Seconds an NPC's loot is reserved for its killer
+	LootOwnershipTime int
+
+	// Odds that a missed arrow is left on the ground
+	ArrowDropChance float64
+
+	// Merchants
+	CommerceSellFraction    float64 // Share of Object.Value paid when selling to a merchant
+	CommerceRestockInterval int     // seconds
+
+	// Skill progression through use
+	SkillGainChance     float64 // Odds of +1 per successful use at skill 0, shrinking towards MaxSkillPoints
+	SkillMinGainChance  float64 // Floor for those odds
+	SkillPointsPerLevel int     // Skills can't be trained past Level * SkillPointsPerLevel
+	SkillGainInterval   int     // Minimum seconds between two skill gains of the same character
+
+	// Class specials in melee
+	BackstabChance float64 // Odds at Stab skill 100 of an assassin stabbing from behind
+	BackstabDamage float64 // Damage multiplier of a backstab (doubled again against creatures)
+	CriticalChance float64 // Odds of a bandit landing a critical hit
+	CriticalDamage float64 // Damage multiplier of a critical hit
+	StunChance     float64 // Odds at Wrestling skill 100 of an unarmed hit stunning the victim
+	StunDuration   int     // milliseconds
+
+	// Seconds strength and agility spells last
+	SpellAttributeDuration int
+
+	// Milliseconds between hits of spell fields on whoever stands on them
+	SpellFieldInterval int
+
+	// Tiles away spells without a range of their own reach
+	SpellRange int
+
+	// Seconds a mimicry disguise lasts
+	MimicryDuration int
 }
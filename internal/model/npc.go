@@ -60,21 +60,54 @@ type NPC struct {
 	CastsSpells int
 	Spells      []int
 	DoubleAttack  bool
+	Poisons         bool // Veneno: hits may poison the victim
+	ExactHit        bool // GolpeExacto: hits never miss
+	ParalysisImmune bool // AfectaParalisis: cannot be paralyzed or immobilized
 	Respawn     bool
+	RespawnTime int  // Seconds, 0 uses the global default
+	FixedOrigin bool // PosOrig: respawns at and returns to its original position
 
 	// Trading
 	CanTrade  bool
 	Inventory []InventorySlot
+	ItemTypes []ObjectType // TipoItems: object types the merchant buys
+	Restock   bool         // Stock is refilled periodically unless InvReSpawn=1
 
 	// Spawning
 	Movement int
 
-	Drops []NPCDrop
+	// Loot
+	GiveGold int
+	Drops    []NPCDrop
+}
+
+// MaxNpcDrops is the amount of DropN entries read from npcs.dat.
+const MaxNpcDrops = 10
+
+// NpcPoisonChance is the percent chance a poisonous NPC's hit poisons its victim.
+const NpcPoisonChance = 30
+
+// NpcBuysAnyItem is the TipoItems value of merchants that buy objects of any type.
+const NpcBuysAnyItem ObjectType = 1000
+
+// MaxPets is how many summoned or tamed creatures a player can control at once.
+const MaxPets = 3
+
+// BuysItemType reports whether the merchant accepts objects of the given type.
+func (n *NPC) BuysItemType(t ObjectType) bool {
+	for _, it := range n.ItemTypes {
+		if it == NpcBuysAnyItem || it == t {
+			return true
+		}
+	}
+	return false
 }
 
 type NPCDrop struct {
-	ObjectID int
-	Amount   int
+	ObjectID  int
+	MinAmount int
+	MaxAmount int
+	Chance    float64 // percent, 0-100
 }
 
 type WorldNPC struct {
@@ -89,12 +122,26 @@ type WorldNPC struct {
 	Immobilized bool
 	Paralyzed   bool
 	ParalyzedSince time.Time
+	StunnedUntil   time.Time
 	OldMovement  int
 	OldHostile   bool
 	AttackedBy   string
+	TargetNpc    int16 // Index of the NPC it is fighting
 	Follow       bool
 	OwnerIndex  int // Index of the user who owns this NPC
+	SpawnedBy   string // GM who summoned it, empty for map and respawned NPCs
 	Respawn      bool
+	Origin       Position
+	SpawnRegion  *SpawnRegion
+
+	// Merchant stock of this instance, refilled from NPC.Inventory
+	Inventory   []InventorySlot
+	LastRestock time.Time
+
+	// Cached path towards PathDest, consumed step by step
+	Path      []Position
+	PathDest  Position
+	PathRetry time.Time // After a failed search, wait until then before searching again
 
 	// Intervals
 	LastAttack time.Time
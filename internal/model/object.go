@@ -1,5 +1,10 @@
 package model
 
+import (
+	"strings"
+	"time"
+)
+
 type ObjectType int
 
 const (
@@ -28,7 +33,8 @@ const (
 	OTMetal
 	OTParchment
 	
-	OTBoat ObjectType = 31
+	OTBoat  ObjectType = 31
+	OTArrow ObjectType = 32
 )
 
 type Object struct {
@@ -43,6 +49,12 @@ type Object struct {
 	MaxHit int
 	MinDef int
 	MaxDef int
+
+	// Magic
+	MagicPower       int // Spells with a NeedStaff up to this can be cast wielding it
+	StaffDamageBonus int // Percent added to the damage of staff affected spells
+	MinMagicDef      int // Damage taken off incoming spells, rolled between min and max
+	MaxMagicDef      int
 	
 	// Requirements
 	MinLevel int
@@ -76,6 +88,8 @@ type Object struct {
 	// Map interaction
 	Pickupable bool
 	Ranged     bool
+	NeedsAmmo  bool // Ranged weapons shoot the equipped OTArrow unless this is false
+	Stabbing   bool // Daggers and the like, which let assassins backstab
 
 	// Doors
 	OpenIndex   int
@@ -88,4 +102,12 @@ type Object struct {
 type WorldObject struct {
 	Object *Object
 	Amount int
+
+	// Loot ownership: only Owner can pick it up until OwnerUntil
+	Owner      string
+	OwnerUntil time.Time
+}
+
+func (o *WorldObject) CanBePickedUpBy(name string) bool {
+	return o.Owner == "" || strings.EqualFold(o.Owner, name) || time.Now().After(o.OwnerUntil)
 }
@@ -0,0 +1,99 @@
+package model
+
+import "container/heap"
+
+type pathNode struct {
+	index int
+	cost  int // steps from start
+	score int // cost + heuristic
+}
+
+type pathQueue []pathNode
+
+func (q pathQueue) Len() int            { return len(q) }
+func (q pathQueue) Less(i, j int) bool  { return q[i].score < q[j].score }
+func (q pathQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *pathQueue) Push(x interface{}) { *q = append(*q, x.(pathNode)) }
+func (q *pathQueue) Pop() interface{} {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
+
+// FindPath runs an A* search over the map tiles from start to goal, moving in the four
+// cardinal directions. walkable decides which tiles can be stepped on; the goal itself is
+// always accepted so a path can lead up to an occupied tile. The search gives up after
+// expanding maxNodes tiles. It returns the steps to take (excluding start, nil if there is
+// no path) and the amount of tiles expanded.
+func (m *Map) FindPath(start, goal Position, maxNodes int, walkable func(x, y int) bool) ([]Position, int) {
+	if start.Map != goal.Map || start == goal {
+		return nil, 0
+	}
+
+	startIdx := int(start.Y)*MapWidth + int(start.X)
+	goalIdx := int(goal.Y)*MapWidth + int(goal.X)
+
+	heuristic := func(idx int) int {
+		return absInt(idx%MapWidth-int(goal.X)) + absInt(idx/MapWidth-int(goal.Y))
+	}
+
+	cameFrom := map[int]int{startIdx: -1}
+	costs := map[int]int{startIdx: 0}
+	open := &pathQueue{{index: startIdx, score: heuristic(startIdx)}}
+
+	expanded := 0
+	for open.Len() > 0 && expanded < maxNodes {
+		current := heap.Pop(open).(pathNode)
+		if current.cost > costs[current.index] {
+			continue // stale entry
+		}
+		if current.index == goalIdx {
+			return m.buildPath(cameFrom, goalIdx), expanded
+		}
+		expanded++
+
+		x, y := current.index%MapWidth, current.index/MapWidth
+		for _, d := range [4][2]int{{0, -1}, {1, 0}, {0, 1}, {-1, 0}} {
+			nx, ny := x+d[0], y+d[1]
+			if nx < 0 || ny < 0 || nx >= MapWidth || ny >= MapHeight {
+				continue
+			}
+
+			next := ny*MapWidth + nx
+			if next != goalIdx && !walkable(nx, ny) {
+				continue
+			}
+
+			cost := current.cost + 1
+			if prev, seen := costs[next]; seen && prev <= cost {
+				continue
+			}
+			costs[next] = cost
+			cameFrom[next] = current.index
+			heap.Push(open, pathNode{index: next, cost: cost, score: cost + heuristic(next)})
+		}
+	}
+
+	return nil, expanded
+}
+
+func (m *Map) buildPath(cameFrom map[int]int, goalIdx int) []Position {
+	var reversed []Position
+	for idx := goalIdx; cameFrom[idx] != -1; idx = cameFrom[idx] {
+		reversed = append(reversed, Position{X: byte(idx % MapWidth), Y: byte(idx / MapWidth), Map: m.Id})
+	}
+
+	path := make([]Position, len(reversed))
+	for i, pos := range reversed {
+		path[len(reversed)-1-i] = pos
+	}
+	return path
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
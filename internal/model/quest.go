@@ -0,0 +1,84 @@
+package model
+
+type QuestObjectiveType int
+
+const (
+	QuestKillNpc QuestObjectiveType = iota + 1
+	QuestCollectObject
+	QuestReachPosition
+	QuestTalkToNpc
+)
+
+// MaxActiveQuests is the amount of quests a character can have in progress at once.
+const MaxActiveQuests = 5
+
+type QuestObjective struct {
+	Type     QuestObjectiveType
+	TargetID int // NPC ID for kill/talk objectives, object ID for collect objectives
+	Amount   int
+	Position Position // Only used by reach objectives
+}
+
+type QuestReward struct {
+	ObjectID int
+	Amount   int
+}
+
+type Quest struct {
+	ID          int
+	Name        string
+	Description string
+	GiverNPC    int
+	MinLevel    int
+	Objectives  []QuestObjective
+
+	RewardGold  int
+	RewardExp   int
+	RewardItems []QuestReward
+}
+
+// QuestProgress tracks an accepted quest. Progress holds one counter per objective;
+// collect objectives are checked against the inventory and don't use it.
+type QuestProgress struct {
+	QuestID  int
+	Progress []int
+}
+
+func (c *Character) GetQuestProgress(questID int) *QuestProgress {
+	for _, q := range c.Quests {
+		if q.QuestID == questID {
+			return q
+		}
+	}
+	return nil
+}
+
+func (c *Character) HasCompletedQuest(questID int) bool {
+	for _, id := range c.CompletedQuests {
+		if id == questID {
+			return true
+		}
+	}
+	return false
+}
+
+func (inv *Inventory) CountItem(objectID int) int {
+	total := 0
+	for i := 0; i < InventorySlots; i++ {
+		if inv.Slots[i].ObjectID == objectID {
+			total += inv.Slots[i].Amount
+		}
+	}
+	return total
+}
+
+// CountUnequippedItem counts the units of objectID the character carries but doesn't wear.
+func (inv *Inventory) CountUnequippedItem(objectID int) int {
+	total := 0
+	for i := 0; i < InventorySlots; i++ {
+		if inv.Slots[i].ObjectID == objectID && !inv.Slots[i].Equipped {
+			total += inv.Slots[i].Amount
+		}
+	}
+	return total
+}
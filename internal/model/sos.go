@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// MaxSosMessageLength caps the text a player can attach to a help request.
+const MaxSosMessageLength = 200
+
+// SosTicket is a player's request for help from a game master.
+type SosTicket struct {
+	ID        int
+	Author    string
+	Message   string
+	Position  Position // Where the author was when asking for help
+	CreatedAt time.Time
+	ClaimedBy string // GM handling the ticket, empty while unattended
+}
@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+// SpawnRegion is an area of a map kept populated with up to Max NPCs of each kind.
+type SpawnRegion struct {
+	Name string
+	Map  int
+	X1   byte
+	Y1   byte
+	X2   byte
+	Y2   byte
+	Npcs []SpawnRegionNpc
+}
+
+type SpawnRegionNpc struct {
+	NpcID       int
+	Max         int
+	RespawnTime int // Seconds, 0 falls back to the NPC's own respawn time
+}
+
+func (r *SpawnRegion) Contains(pos Position) bool {
+	return pos.Map == r.Map && pos.X >= r.X1 && pos.X <= r.X2 && pos.Y >= r.Y1 && pos.Y <= r.Y2
+}
+
+func (r *SpawnRegion) GetNpc(npcID int) *SpawnRegionNpc {
+	for i := range r.Npcs {
+		if r.Npcs[i].NpcID == npcID {
+			return &r.Npcs[i]
+		}
+	}
+	return nil
+}
+
+// PendingRespawn is a dead NPC waiting to come back.
+type PendingRespawn struct {
+	NpcID  int
+	Map    int
+	Origin *Position    // Fixed-origin NPCs come back here
+	Region *SpawnRegion // Region NPCs come back anywhere inside it
+	DueAt  time.Time
+}
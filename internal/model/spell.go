@@ -1,5 +1,8 @@
 package model
 
+// MaxSpells is the number of spell slots a character's spellbook holds.
+const MaxSpells = 35
+
 type SpellTarget int
 
 const (
@@ -41,6 +44,26 @@ type Spell struct {
 		MinHP int
 		MaxHP int
 		SubeHP int // 1: Heal, 2: Damage
+
+		// Other stats, raised (1) or lowered (2) like SubeHP
+		SubeMana     int
+		MinMana      int
+		MaxMana      int
+		SubeStamina  int
+		MinStamina   int
+		MaxStamina   int
+		SubeHunger   int
+		MinHunger    int
+		MaxHunger    int
+		SubeThirst   int
+		MinThirst    int
+		MaxThirst    int
+		SubeAgility  int
+		MinAgility   int
+		MaxAgility   int
+		SubeStrength int
+		MinStrength  int
+		MaxStrength  int
 		
 		// Effects
 		Invisibility   bool
@@ -56,7 +79,19 @@ type Spell struct {
 		// Summoning
 		SummonNPC      int
 		SummonAmount   int
+
+		// Disguise
+		Mimic          bool // Copies the looks of the target onto the caster
+
+		// Reach
+		Range          int // Tiles away the target may be, 0 for the global default
+
+		// Area
+		AreaRadius     int // Tiles around the target tile the spell reaches, 0 for the tile alone
+		FieldDuration  int // Seconds the spell keeps hitting whoever stands in its area
+		FieldGraphic   int // Grh shown on the ground of the field's tiles while it lasts
 		
 		// Requirements
-		NeedStaff      int
+		NeedStaff      int  // Magic power of the staff mages need to cast it
+		StaffAffected  bool // Damage grows with the caster's staff
 	}
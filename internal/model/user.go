@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"sync"
+	"time"
+)
 
 type KillType int
 
@@ -89,6 +92,10 @@ type Character struct {
 	Head         int
 	OriginalHead int
 
+	// Own looks of a character disguised by a mimicry effect
+	UndisguisedBody int
+	UndisguisedHead int
+
 	Weapon int16
 	Shield int16
 	Helmet int16
@@ -102,21 +109,32 @@ type Character struct {
 	Spells        []int
 	SelectedSpell int
 
-	Poisoned    bool
-	Paralyzed   bool
-	Immobilized bool
-	Invisible   bool
-	Meditating  bool
-	Sailing     bool
-	Dead        bool
-	Hidden      bool
+	Quests          []*QuestProgress
+	CompletedQuests []int
+
+	Invisible  bool
+	Meditating bool
+	Sailing    bool
+	Dead       bool
+	Hidden     bool
+
+	// Poison, paralysis, stuns and attribute changes, see effect.go. Connections, the AI and
+	// the timed events all change them, so they are only reached through effectsMu
+	Effects   map[EffectType]*Effect
+	effectsMu sync.Mutex
+
+	// GM moderation flags
+	Silenced       bool
+	AdminInvisible bool
 
-	// Paralysis tracking
-	ParalyzedSince time.Time
+	// Skill progression through use
+	LastSkillGain time.Time
 
 	// Stats counters
-	Kills    map[KillType]int
-	JailTime int64
+	Kills     map[KillType]int
+	JailTime  int64
+	DuelsWon  int
+	DuelsLost int
 
 	// Targets
 	TargetMap     int
@@ -143,9 +161,10 @@ type Character struct {
 	LastMeditationRegen time.Time
 	MeditatingSince     time.Time
 
-	// Effect Timers
-	StrengthEffectEnd time.Time
-	AgilityEffectEnd  time.Time
+	// Anti-picket and jail tracking
+	AntiPicketSince       time.Time
+	LastAntiPicketWarning time.Time
+	LastJailTick          time.Time
 }
 
 type InventorySlot struct {
@@ -206,5 +225,6 @@ func NewCharacter(name string, race Race, gender Gender, archetype UserArchetype
 		OriginalAttributes: make(map[Attribute]byte),
 		Skills:             make(map[Skill]int),
 		Kills:              make(map[KillType]int),
+		Effects:            make(map[EffectType]*Effect),
 	}
 }
@@ -2,6 +2,7 @@ package persistence
 
 import (
 	"os"
+	"strings"
 
 	"github.com/ao-go-server/internal/model"
 	"gopkg.in/yaml.v3"
@@ -15,10 +16,21 @@ func NewBalanceYamlRepo(path string) *BalanceYamlRepo {
 	return &BalanceYamlRepo{path: path}
 }
 
+type yamlPosition struct {
+	Map int  `yaml:"map"`
+	X   byte `yaml:"x"`
+	Y   byte `yaml:"y"`
+}
+
+func (p yamlPosition) toPosition() model.Position {
+	return model.Position{Map: p.Map, X: p.X, Y: p.Y}
+}
+
 type yamlBalance struct {
 	Balance struct {
 		Races        map[string]map[string]int     `yaml:"races"`
 		Classes      map[string]map[string]float32 `yaml:"classes"`
+		Forbidden    map[string][]string           `yaml:"forbidden_classes"`
 		Distribution struct {
 			E []int `yaml:"e"`
 			S []int `yaml:"s"`
@@ -48,7 +60,60 @@ type yamlBalance struct {
 				Attack    int64 `yaml:"attack"`
 				Paralyzed int64 `yaml:"paralyzed"`
 			} `yaml:"intervals"`
+			RespawnTime int `yaml:"respawn_time"`
 		} `yaml:"npc"`
+		Gambling struct {
+			HouseEdge float64 `yaml:"house_edge"`
+			MaxBet    int     `yaml:"max_bet"`
+			HourlyCap int     `yaml:"hourly_cap"`
+		} `yaml:"gambling"`
+		Duels struct {
+			Countdown        int `yaml:"countdown"`
+			ChallengeTimeout int `yaml:"challenge_timeout"`
+			MaxDuration      int `yaml:"max_duration"`
+			ArenaExit        yamlPosition `yaml:"arena_exit"`
+		} `yaml:"duels"`
+		AntiPicket struct {
+			WarnInterval int    `yaml:"warn_interval"`
+			Limit        int    `yaml:"limit"`
+			Action       string `yaml:"action"`
+			JailTime     int    `yaml:"jail_time"`
+		} `yaml:"anti_picket"`
+		Jail struct {
+			Position yamlPosition `yaml:"position"`
+			Exit     yamlPosition `yaml:"exit"`
+			Radius   int          `yaml:"radius"`
+		} `yaml:"jail"`
+		Loot struct {
+			OwnershipTime int `yaml:"ownership_time"`
+		} `yaml:"loot"`
+		Ranged struct {
+			ArrowDropChance float64 `yaml:"arrow_drop_chance"`
+		} `yaml:"ranged"`
+		Commerce struct {
+			SellFraction    float64 `yaml:"sell_fraction"`
+			RestockInterval int     `yaml:"restock_interval"`
+		} `yaml:"commerce"`
+		Skills struct {
+			GainChance     float64 `yaml:"gain_chance"`
+			MinGainChance  float64 `yaml:"min_gain_chance"`
+			PointsPerLevel int     `yaml:"points_per_level"`
+			GainInterval   int     `yaml:"gain_interval"`
+		} `yaml:"skills"`
+		Specials struct {
+			BackstabChance float64 `yaml:"backstab_chance"`
+			BackstabDamage float64 `yaml:"backstab_damage"`
+			CriticalChance float64 `yaml:"critical_chance"`
+			CriticalDamage float64 `yaml:"critical_damage"`
+			StunChance     float64 `yaml:"stun_chance"`
+			StunDuration   int     `yaml:"stun_duration"`
+		} `yaml:"specials"`
+		Spells struct {
+			AttributeDuration int `yaml:"attribute_duration"`
+			FieldInterval     int `yaml:"field_interval"`
+			Range             int `yaml:"range"`
+			MimicryDuration   int `yaml:"mimicry_duration"`
+		} `yaml:"spells"`
 	} `yaml:"balance"`
 }
 
@@ -85,6 +150,39 @@ func (d *BalanceYamlRepo) Load() (map[model.UserArchetype]*model.ArchetypeModifi
 		NPCIntervalMove:         yb.Balance.NPC.Intervals.MoveSpeed,
 		NPCIntervalAttack:       yb.Balance.NPC.Intervals.Attack,
 		NPCParalizedTime:        yb.Balance.NPC.Intervals.Paralyzed * 60 * 1000, // min to ms
+		NPCRespawnTime:          yb.Balance.NPC.RespawnTime,
+		GamblingHouseEdge:       yb.Balance.Gambling.HouseEdge,
+		GamblingMaxBet:          yb.Balance.Gambling.MaxBet,
+		GamblingHourlyCap:       yb.Balance.Gambling.HourlyCap,
+		DuelCountdown:           yb.Balance.Duels.Countdown,
+		DuelChallengeTimeout:    yb.Balance.Duels.ChallengeTimeout,
+		DuelMaxDuration:         yb.Balance.Duels.MaxDuration,
+		ArenaExit:               yb.Balance.Duels.ArenaExit.toPosition(),
+		AntiPicketWarnInterval:  yb.Balance.AntiPicket.WarnInterval,
+		AntiPicketLimit:         yb.Balance.AntiPicket.Limit,
+		AntiPicketJail:          strings.EqualFold(yb.Balance.AntiPicket.Action, "jail"),
+		AntiPicketJailTime:      yb.Balance.AntiPicket.JailTime,
+		JailPosition:            yb.Balance.Jail.Position.toPosition(),
+		JailExit:                yb.Balance.Jail.Exit.toPosition(),
+		JailRadius:              yb.Balance.Jail.Radius,
+		LootOwnershipTime:       yb.Balance.Loot.OwnershipTime,
+		ArrowDropChance:         yb.Balance.Ranged.ArrowDropChance,
+		CommerceSellFraction:    yb.Balance.Commerce.SellFraction,
+		CommerceRestockInterval: yb.Balance.Commerce.RestockInterval,
+		SkillGainChance:         yb.Balance.Skills.GainChance,
+		SkillMinGainChance:      yb.Balance.Skills.MinGainChance,
+		SkillPointsPerLevel:     yb.Balance.Skills.PointsPerLevel,
+		SkillGainInterval:       yb.Balance.Skills.GainInterval,
+		BackstabChance:          yb.Balance.Specials.BackstabChance,
+		BackstabDamage:          yb.Balance.Specials.BackstabDamage,
+		CriticalChance:          yb.Balance.Specials.CriticalChance,
+		CriticalDamage:          yb.Balance.Specials.CriticalDamage,
+		StunChance:              yb.Balance.Specials.StunChance,
+		StunDuration:            yb.Balance.Specials.StunDuration,
+		SpellAttributeDuration:  yb.Balance.Spells.AttributeDuration,
+		SpellFieldInterval:      yb.Balance.Spells.FieldInterval,
+		SpellRange:              yb.Balance.Spells.Range,
+		MimicryDuration:         yb.Balance.Spells.MimicryDuration,
 	}
 
 	// ... (Races and Classes mapping)
@@ -138,5 +236,17 @@ func (d *BalanceYamlRepo) Load() (map[model.UserArchetype]*model.ArchetypeModifi
 		}
 	}
 
+	for name, r := range raceMap {
+		mods, ok := raceModifiers[r]
+		if !ok {
+			continue
+		}
+		for _, class := range yb.Balance.Forbidden[name] {
+			if a, ok := classMap[class]; ok {
+				mods.ForbiddenArchetypes = append(mods.ForbiddenArchetypes, a)
+			}
+		}
+	}
+
 	return archetypeModifiers, raceModifiers, globalConfig, nil
 }
@@ -0,0 +1,51 @@
+package persistence
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+)
+
+type BanIPDatRepo struct {
+	path string
+}
+
+func NewBanIPDatRepo(path string) *BanIPDatRepo {
+	return &BanIPDatRepo{path: path}
+}
+
+// Load returns the banned IP addresses. A missing file means no bans.
+func (d *BanIPDatRepo) Load() ([]string, error) {
+	data, err := ReadINI(d.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	count := toInt(data["INIT"]["CANTIDAD"])
+	ips := make([]string, 0, count)
+	for i := 1; i <= count; i++ {
+		if ip := data["BANIPS"][fmt.Sprintf("IP%d", i)]; ip != "" {
+			ips = append(ips, ip)
+		}
+	}
+	return ips, nil
+}
+
+func (d *BanIPDatRepo) Save(ips []string) error {
+	file, err := os.Create(d.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	fmt.Fprintf(writer, "[INIT]\r\nCantidad=%d\r\n\r\n[BANIPS]\r\n", len(ips))
+	for i, ip := range ips {
+		fmt.Fprintf(writer, "IP%d=%s\r\n", i+1, ip)
+	}
+	return writer.Flush()
+}
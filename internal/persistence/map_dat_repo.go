@@ -16,6 +16,7 @@ type MapDatRepo struct {
 	mapsAmount int
 	waterGrhs  map[int16]bool
 	lavaGrhs   map[int16]bool
+	regions    map[int][]*model.SpawnRegion
 }
 
 func NewMapDatRepo(mapsPath string, mapsAmount int) *MapDatRepo {
@@ -24,6 +25,7 @@ func NewMapDatRepo(mapsPath string, mapsAmount int) *MapDatRepo {
 		mapsAmount: mapsAmount,
 		waterGrhs:  make(map[int16]bool),
 		lavaGrhs:   make(map[int16]bool),
+		regions:    make(map[int][]*model.SpawnRegion),
 	}
 }
 
@@ -37,9 +39,24 @@ type yamlMaps struct {
 			Water []string `yaml:"water"`
 			Lava  []string `yaml:"lava"`
 		} `yaml:"tiles"`
+		SpawnRegions []yamlSpawnRegion `yaml:"spawn_regions"`
 	} `yaml:"maps"`
 }
 
+type yamlSpawnRegion struct {
+	Name string `yaml:"name"`
+	Map  int    `yaml:"map"`
+	X1   byte   `yaml:"x1"`
+	Y1   byte   `yaml:"y1"`
+	X2   byte   `yaml:"x2"`
+	Y2   byte   `yaml:"y2"`
+	Npcs []struct {
+		ID          int `yaml:"id"`
+		Max         int `yaml:"max"`
+		RespawnTime int `yaml:"respawn_time"`
+	} `yaml:"npcs"`
+}
+
 func (d *MapDatRepo) LoadProperties(path string) error {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -58,9 +75,29 @@ func (d *MapDatRepo) LoadProperties(path string) error {
 		d.parseRanges(r, d.lavaGrhs)
 	}
 
+	d.regions = make(map[int][]*model.SpawnRegion)
+	for _, yr := range ym.Maps.SpawnRegions {
+		region := &model.SpawnRegion{
+			Name: yr.Name,
+			Map:  yr.Map,
+			X1:   min(yr.X1, yr.X2),
+			Y1:   min(yr.Y1, yr.Y2),
+			X2:   max(yr.X1, yr.X2),
+			Y2:   max(yr.Y1, yr.Y2),
+		}
+		for _, n := range yr.Npcs {
+			region.Npcs = append(region.Npcs, model.SpawnRegionNpc{NpcID: n.ID, Max: n.Max, RespawnTime: n.RespawnTime})
+		}
+		d.regions[region.Map] = append(d.regions[region.Map], region)
+	}
+
 	return nil
 }
 
+func (d *MapDatRepo) GetSpawnRegions(mapID int) []*model.SpawnRegion {
+	return d.regions[mapID]
+}
+
 func (d *MapDatRepo) parseRanges(r string, target map[int16]bool) {
 	r = strings.TrimSpace(r)
 	bounds := strings.Split(r, "-")
@@ -0,0 +1,49 @@
+package persistence
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+)
+
+type MotdDatRepo struct {
+	path string
+}
+
+func NewMotdDatRepo(path string) *MotdDatRepo {
+	return &MotdDatRepo{path: path}
+}
+
+// Load returns the message of the day, one entry per line. A missing file means no message.
+func (d *MotdDatRepo) Load() ([]string, error) {
+	data, err := ReadINI(d.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	count := toInt(data["INIT"]["NUMLINES"])
+	lines := make([]string, 0, count)
+	for i := 1; i <= count; i++ {
+		lines = append(lines, data["MOTD"][fmt.Sprintf("LINE%d", i)])
+	}
+	return lines, nil
+}
+
+func (d *MotdDatRepo) Save(lines []string) error {
+	file, err := os.Create(d.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	fmt.Fprintf(writer, "[INIT]\r\nNumLines=%d\r\n\r\n[MOTD]\r\n", len(lines))
+	for i, line := range lines {
+		fmt.Fprintf(writer, "Line%d=%s\r\n", i+1, latin1(line))
+	}
+	return writer.Flush()
+}
@@ -89,9 +89,15 @@ func (d *NpcDatRepo) Load() (map[int]*model.NPC, error) {
 			Hostile:     props["HOSTILE"] == "1",
 			CanTrade:    props["COMERCIA"] == "1",
 			Movement:    toInt(props["MOVEMENT"]),
-			Respawn:     props["RESPAWN"] == "1" || props["RE_SPAWN"] == "1",
+			Respawn:     props["RESPAWN"] != "1" && props["RE_SPAWN"] != "1", // ReSpawn=1 means the NPC never comes back
+			RespawnTime: toInt(props["RESPAWNTIME"]),
+			FixedOrigin: props["POSORIG"] == "1",
 			CastsSpells: toInt(props["LANZASPELLS"]),
 			DoubleAttack:  props["ATACADOBLE"] == "1",
+			Poisons:         props["VENENO"] == "1",
+			ExactHit:        props["GOLPEEXACTO"] == "1",
+			ParalysisImmune: props["AFECTAPARALISIS"] == "1",
+			GiveGold:    toInt(props["GIVEGLD"]),
 		}
 
 		if npc.CastsSpells > 0 {
@@ -117,6 +123,13 @@ func (d *NpcDatRepo) Load() (map[int]*model.NPC, error) {
 					}
 				}
 			}
+
+			for _, t := range strings.Split(props["TIPOITEMS"], "-") {
+				if t = strings.TrimSpace(t); t != "" {
+					npc.ItemTypes = append(npc.ItemTypes, model.ObjectType(toInt(t)))
+				}
+			}
+			npc.Restock = props["INVRESPAWN"] != "1"
 		}
 
 		// HP can be MinHP/MaxHP or just HP
@@ -133,15 +146,11 @@ func (d *NpcDatRepo) Load() (map[int]*model.NPC, error) {
 		}
 
 		// Load drops
-		for i := 1; i <= 10; i++ {
+		for i := 1; i <= model.MaxNpcDrops; i++ {
 			dropKey := fmt.Sprintf("DROP%d", i)
 			if val, ok := props[dropKey]; ok {
-				parts := strings.Split(val, "-")
-				if len(parts) == 2 {
-					npc.Drops = append(npc.Drops, model.NPCDrop{
-						ObjectID: toInt(parts[0]),
-						Amount:   toInt(parts[1]),
-					})
+				if drop, ok := parseNpcDrop(val); ok {
+					npc.Drops = append(npc.Drops, drop)
 				}
 			}
 		}
@@ -151,3 +160,37 @@ func (d *NpcDatRepo) Load() (map[int]*model.NPC, error) {
 
 	return npcs, nil
 }
+
+// parseNpcDrop reads ObjID-Amount, ObjID-Amount-Chance or ObjID-MinAmount-MaxAmount-Chance.
+// Chance is a percentage and defaults to 100.
+func parseNpcDrop(val string) (model.NPCDrop, bool) {
+	parts := strings.Split(val, "-")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	drop := model.NPCDrop{ObjectID: toInt(parts[0]), Chance: 100}
+	switch len(parts) {
+	case 2:
+		drop.MinAmount = toInt(parts[1])
+		drop.MaxAmount = drop.MinAmount
+	case 3:
+		drop.MinAmount = toInt(parts[1])
+		drop.MaxAmount = drop.MinAmount
+		drop.Chance, _ = strconv.ParseFloat(parts[2], 64)
+	case 4:
+		drop.MinAmount = toInt(parts[1])
+		drop.MaxAmount = toInt(parts[2])
+		drop.Chance, _ = strconv.ParseFloat(parts[3], 64)
+	default:
+		return model.NPCDrop{}, false
+	}
+
+	if drop.ObjectID <= 0 || drop.MinAmount <= 0 {
+		return model.NPCDrop{}, false
+	}
+	if drop.MaxAmount < drop.MinAmount {
+		drop.MaxAmount = drop.MinAmount
+	}
+	return drop, true
+}
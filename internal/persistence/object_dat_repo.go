@@ -61,6 +61,10 @@ func (d *ObjectDatRepo) Load() (map[int]*model.Object, error) {
 		obj.MaxHit = toInt(props["MAX_HIT"])
 		obj.MinDef = toInt(props["MIN_DEF"])
 		obj.MaxDef = toInt(props["MAX_DEF"])
+		obj.MagicPower = toInt(props["MAGIC_POWER"])
+		obj.StaffDamageBonus = toInt(props["STAFF_DAMAGE_BONUS"])
+		obj.MinMagicDef = toInt(props["MIN_MAGIC_DEFENSE"])
+		obj.MaxMagicDef = toInt(props["MAX_MAGIC_DEFENSE"])
 
 		// Requirements
 		obj.MinLevel = toInt(props["MIN_LEVEL"])
@@ -121,6 +125,8 @@ func (d *ObjectDatRepo) Load() (map[int]*model.Object, error) {
 		} else if p, ok := props["RANGED_WEAPON"]; ok {
 			obj.Ranged = p == "1"
 		}
+		obj.NeedsAmmo = obj.Ranged && props["AMMO"] != "0"
+		obj.Stabbing = props["STABBING"] == "1"
 
 		// Doors
 		obj.OpenIndex = toInt(props["OPEN_INDEX"])
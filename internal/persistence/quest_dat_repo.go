@@ -0,0 +1,105 @@
+package persistence
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/ao-go-server/internal/model"
+)
+
+type QuestDatRepo struct {
+	path string
+}
+
+func NewQuestDatRepo(path string) *QuestDatRepo {
+	return &QuestDatRepo{path: path}
+}
+
+func (d *QuestDatRepo) Load() (map[int]*model.Quest, error) {
+	data, err := ReadINI(d.path)
+	if err != nil {
+		return nil, err
+	}
+
+	quests := make(map[int]*model.Quest)
+
+	for section, props := range data {
+		if !strings.HasPrefix(section, "QUEST") {
+			continue
+		}
+
+		id, err := strconv.Atoi(section[5:])
+		if err != nil {
+			continue
+		}
+
+		quest := &model.Quest{
+			ID:          id,
+			Name:        props["NOMBRE"],
+			Description: props["DESC"],
+			GiverNPC:    toInt(props["NPCDADOR"]),
+			MinLevel:    toInt(props["NIVELMINIMO"]),
+			RewardGold:  toInt(props["ORO"]),
+			RewardExp:   toInt(props["EXP"]),
+		}
+
+		numObjectives := toInt(props["NUMOBJETIVOS"])
+		for i := 1; i <= numObjectives; i++ {
+			objective, ok := parseQuestObjective(props[fmt.Sprintf("OBJETIVO%d", i)])
+			if !ok {
+				slog.Warn("Invalid quest objective", "quest", id, "objective", i)
+				continue
+			}
+			quest.Objectives = append(quest.Objectives, objective)
+		}
+
+		numRewards := toInt(props["NUMPREMIOS"])
+		for i := 1; i <= numRewards; i++ {
+			parts := strings.Split(props[fmt.Sprintf("PREMIO%d", i)], "-")
+			if len(parts) < 2 {
+				continue
+			}
+			quest.RewardItems = append(quest.RewardItems, model.QuestReward{
+				ObjectID: toInt(parts[0]),
+				Amount:   toInt(parts[1]),
+			})
+		}
+
+		quests[id] = quest
+	}
+
+	return quests, nil
+}
+
+// parseQuestObjective reads objectives in the form TYPE-arg1-arg2..., e.g. MATAR-77-10 or LLEGAR-1-58-45.
+func parseQuestObjective(val string) (model.QuestObjective, bool) {
+	parts := strings.Split(val, "-")
+	if len(parts) < 2 {
+		return model.QuestObjective{}, false
+	}
+
+	switch strings.ToUpper(parts[0]) {
+	case "MATAR":
+		if len(parts) < 3 {
+			return model.QuestObjective{}, false
+		}
+		return model.QuestObjective{Type: model.QuestKillNpc, TargetID: toInt(parts[1]), Amount: toInt(parts[2])}, true
+	case "JUNTAR":
+		if len(parts) < 3 {
+			return model.QuestObjective{}, false
+		}
+		return model.QuestObjective{Type: model.QuestCollectObject, TargetID: toInt(parts[1]), Amount: toInt(parts[2])}, true
+	case "LLEGAR":
+		if len(parts) < 4 {
+			return model.QuestObjective{}, false
+		}
+		pos := model.Position{Map: toInt(parts[1]), X: byte(toInt(parts[2])), Y: byte(toInt(parts[3]))}
+		return model.QuestObjective{Type: model.QuestReachPosition, Amount: 1, Position: pos}, true
+	case "HABLAR":
+		return model.QuestObjective{Type: model.QuestTalkToNpc, TargetID: toInt(parts[1]), Amount: 1}, true
+	}
+
+	return model.QuestObjective{}, false
+}
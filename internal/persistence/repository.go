@@ -6,6 +6,11 @@ type BalanceRepository interface {
 	Load() (map[model.UserArchetype]*model.ArchetypeModifiers, map[model.Race]*model.RaceModifiers, *model.GlobalBalanceConfig, error)
 }
 
+type BanIPRepository interface {
+	Load() ([]string, error)
+	Save(ips []string) error
+}
+
 type CityRepository interface {
 	Load() (map[int]model.City, error)
 }
@@ -15,6 +20,12 @@ type MapRepository interface {
 	LoadProperties(path string) error
 	Load() ([]*model.Map, error)
 	LoadMap(id int) (*model.Map, error)
+	GetSpawnRegions(mapID int) []*model.SpawnRegion
+}
+
+type MotdRepository interface {
+	Load() ([]string, error)
+	Save(lines []string) error
 }
 
 type NpcRepository interface {
@@ -25,6 +36,15 @@ type ObjectRepository interface {
 	Load() (map[int]*model.Object, error)
 }
 
+type QuestRepository interface {
+	Load() (map[int]*model.Quest, error)
+}
+
+type SosRepository interface {
+	Load() ([]*model.SosTicket, int, error)
+	Save(tickets []*model.SosTicket, nextID int) error
+}
+
 type SpellRepository interface {
 	Load() (map[int]*model.Spell, error)
 }
@@ -0,0 +1,101 @@
+package persistence
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ao-go-server/internal/model"
+)
+
+type SosDatRepo struct {
+	path string
+}
+
+func NewSosDatRepo(path string) *SosDatRepo {
+	return &SosDatRepo{path: path}
+}
+
+// Load returns the open tickets and the next free ticket ID. A missing file means no tickets.
+func (d *SosDatRepo) Load() ([]*model.SosTicket, int, error) {
+	data, err := ReadINI(d.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, 1, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count := toInt(data["INIT"]["CANTIDAD"])
+	nextID := toInt(data["INIT"]["PROXIMO"])
+
+	var tickets []*model.SosTicket
+	for i := 1; i <= count; i++ {
+		props, ok := data[fmt.Sprintf("SOS%d", i)]
+		if !ok {
+			continue
+		}
+
+		ticket := &model.SosTicket{
+			ID:        toInt(props["ID"]),
+			Author:    props["AUTOR"],
+			Message:   props["MENSAJE"],
+			CreatedAt: time.Unix(int64(toInt(props["FECHA"])), 0),
+			ClaimedBy: props["ATIENDE"],
+		}
+		if parts := strings.Split(props["POSICION"], "-"); len(parts) == 3 {
+			ticket.Position = model.Position{Map: toInt(parts[0]), X: byte(toInt(parts[1])), Y: byte(toInt(parts[2]))}
+		}
+		if ticket.ID >= nextID {
+			nextID = ticket.ID + 1
+		}
+		tickets = append(tickets, ticket)
+	}
+
+	if nextID < 1 {
+		nextID = 1
+	}
+	return tickets, nextID, nil
+}
+
+func (d *SosDatRepo) Save(tickets []*model.SosTicket, nextID int) error {
+	file, err := os.Create(d.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	fmt.Fprintf(writer, "[INIT]\r\nCantidad=%d\r\nProximo=%d\r\n\r\n", len(tickets), nextID)
+	for i, t := range tickets {
+		fmt.Fprintf(writer, "[SOS%d]\r\n", i+1)
+		fmt.Fprintf(writer, "ID=%d\r\n", t.ID)
+		fmt.Fprintf(writer, "Autor=%s\r\n", t.Author)
+		fmt.Fprintf(writer, "Mensaje=%s\r\n", latin1(t.Message))
+		fmt.Fprintf(writer, "Posicion=%d-%d-%d\r\n", t.Position.Map, t.Position.X, t.Position.Y)
+		fmt.Fprintf(writer, "Fecha=%s\r\n", strconv.FormatInt(t.CreatedAt.Unix(), 10))
+		fmt.Fprintf(writer, "Atiende=%s\r\n\r\n", t.ClaimedBy)
+	}
+	return writer.Flush()
+}
+
+// latin1 encodes s the way ReadINI decodes it. Quotes would be read back as a comment,
+// so they are replaced along with anything outside Latin-1.
+func latin1(s string) string {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == '\'':
+			out = append(out, '`')
+		case r > 0xFF:
+			out = append(out, '?')
+		default:
+			out = append(out, byte(r))
+		}
+	}
+	return string(out)
+}
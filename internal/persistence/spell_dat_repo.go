@@ -52,6 +52,24 @@ func (d *SpellDatRepo) Load() (map[int]*model.Spell, error) {
 			MinHP:           toInt(props["MINHP"]),
 			MaxHP:           toInt(props["MAXHP"]),
 			SubeHP:          toInt(props["SUBEHP"]),
+			SubeMana:        toInt(props["SUBEMANA"]),
+			MinMana:         toInt(props["MINMANA"]),
+			MaxMana:         toInt(props["MAXMANA"]),
+			SubeStamina:     toInt(props["SUBESTA"]),
+			MinStamina:      toInt(props["MINSTA"]),
+			MaxStamina:      toInt(props["MAXSTA"]),
+			SubeHunger:      toInt(props["SUBEHAM"]),
+			MinHunger:       toInt(props["MINHAM"]),
+			MaxHunger:       toInt(props["MAXHAM"]),
+			SubeThirst:      toInt(props["SUBESED"]),
+			MinThirst:       toInt(props["MINSED"]),
+			MaxThirst:       toInt(props["MAXSED"]),
+			SubeAgility:     toInt(props["SUBEAG"]),
+			MinAgility:      toInt(props["MINAG"]),
+			MaxAgility:      toInt(props["MAXAG"]),
+			SubeStrength:    toInt(props["SUBEFU"]),
+			MinStrength:     toInt(props["MINFU"]),
+			MaxStrength:     toInt(props["MAXFU"]),
 			Invisibility:    props["INVISIBILIDAD"] == "1",
 			Paralyzes:       props["PARALIZA"] == "1",
 			Immobilizes:     props["INMOVILIZA"] == "1",
@@ -63,7 +81,13 @@ func (d *SpellDatRepo) Load() (map[int]*model.Spell, error) {
 			Dumb:            props["ESTUPIDEZ"] == "1",
 			SummonNPC:       toInt(props["NUMNPC"]),
 			SummonAmount:    toInt(props["CANT"]),
+			Mimic:           props["MIMETIZA"] == "1",
+			Range:           toInt(props["RANGO"]),
+			AreaRadius:      toInt(props["RADIO"]),
+			FieldDuration:   toInt(props["DURACIONCAMPO"]),
+			FieldGraphic:    toInt(props["GRHCAMPO"]),
 			NeedStaff:       toInt(props["NEEDSTAFF"]),
+			StaffAffected:   props["STAFFAFFECTED"] == "1",
 		}
 
 		spells[id] = spell
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ao-go-server/internal/model"
 )
@@ -58,6 +59,7 @@ func (d *UserChrRepo) Load(nick string) (*model.Character, error) {
 		OriginalAttributes: make(map[model.Attribute]byte),
 		Skills:             make(map[model.Skill]int),
 		Kills:              make(map[model.KillType]int),
+		Effects:            make(map[model.EffectType]*model.Effect),
 	}
 
 	init := data["INIT"]
@@ -113,6 +115,9 @@ func (d *UserChrRepo) Load(nick string) (*model.Character, error) {
 	char.Gold = toInt(stats["ORO"])
 	char.BankGold = toInt(stats["BANCO"])
 	char.SkillPoints = toInt(stats["SKILLPTS"])
+	char.DuelsWon = toInt(stats["DUELOSGANADOS"])
+	char.DuelsLost = toInt(stats["DUELOSPERDIDOS"])
+	char.JailTime = int64(toInt(data["COUNTERS"]["PENA"]))
 
 	char.Attributes[model.Strength] = byte(toInt(attrs["AT1"]))
 	char.Attributes[model.Dexterity] = byte(toInt(attrs["AT2"]))
@@ -127,8 +132,8 @@ func (d *UserChrRepo) Load(nick string) (*model.Character, error) {
 
 	char.Dead = toInt(flags["MUERTO"]) == 1
 	char.Invisible = toInt(flags["ESCONDIDO"]) == 1
-	char.Paralyzed = toInt(flags["PARALIZADO"]) == 1
 	char.Sailing = toInt(flags["NAVEGANDO"]) == 1
+	char.Silenced = toInt(flags["SILENCIADO"]) == 1
 
 	// Skills
 	if skills != nil {
@@ -176,7 +181,7 @@ func (d *UserChrRepo) Load(nick string) (*model.Character, error) {
 	// Spells
 	spellsData := data["HECHIZOS"]
 	if spellsData != nil {
-		for i := 1; i <= 35; i++ {
+		for i := 1; i <= model.MaxSpells; i++ {
 			key := fmt.Sprintf("H%d", i)
 			if val, ok := spellsData[key]; ok && val != "0" {
 				char.Spells = append(char.Spells, toInt(val))
@@ -184,6 +189,52 @@ func (d *UserChrRepo) Load(nick string) (*model.Character, error) {
 		}
 	}
 
+	// Quests
+	questsData := data["QUESTS"]
+	if questsData != nil {
+		numQuests := toInt(questsData["NUMQUESTS"])
+		for i := 1; i <= numQuests; i++ {
+			parts := strings.Split(questsData[fmt.Sprintf("Q%d", i)], "-")
+			if toInt(parts[0]) == 0 {
+				continue
+			}
+			progress := &model.QuestProgress{QuestID: toInt(parts[0])}
+			for _, p := range parts[1:] {
+				progress.Progress = append(progress.Progress, toInt(p))
+			}
+			char.Quests = append(char.Quests, progress)
+		}
+
+		if done := questsData["COMPLETADAS"]; done != "" {
+			for _, id := range strings.Split(done, "-") {
+				if v := toInt(id); v > 0 {
+					char.CompletedQuests = append(char.CompletedQuests, v)
+				}
+			}
+		}
+	}
+
+	// Effects, with the time they had left when the character logged out
+	effectsData := data["EFECTOS"]
+	if effectsData != nil {
+		now := time.Now()
+		numEffects := toInt(effectsData["NUMEFECTOS"])
+		for i := 1; i <= numEffects; i++ {
+			parts := strings.SplitN(effectsData[fmt.Sprintf("E%d", i)], "-", 3)
+			if len(parts) < 3 || toInt(parts[0]) == 0 {
+				continue
+			}
+			effect := &model.Effect{Type: model.EffectType(toInt(parts[0])), Magnitude: toInt(parts[2])}
+			if remaining := toInt(parts[1]); remaining > 0 {
+				effect.Expires = now.Add(time.Duration(remaining) * time.Millisecond)
+			}
+			char.Effects[effect.Type] = effect
+			if attr, ok := effect.Type.Attribute(); ok {
+				char.Attributes[attr] = byte(int(char.OriginalAttributes[attr]) + effect.Magnitude)
+			}
+		}
+	}
+
 	return char, nil
 }
 
@@ -264,9 +315,14 @@ func (d *UserChrRepo) SaveCharacter(char *model.Character) error {
 	init["GENERO"] = strconv.Itoa(int(char.Gender))
 	init["RAZA"] = strconv.Itoa(int(char.Race))
 	init["CLASE"] = strconv.Itoa(int(char.Archetype))
-	init["HEAD"] = strconv.Itoa(char.Head)
+	// A disguise is not kept over logouts, save the character's own looks
+	body, head := char.Body, char.Head
+	if char.HasEffect(model.EffectMimicry) {
+		body, head = char.UndisguisedBody, char.UndisguisedHead
+	}
+	init["HEAD"] = strconv.Itoa(head)
 	init["ORIGINALHEAD"] = strconv.Itoa(char.OriginalHead)
-	init["BODY"] = strconv.Itoa(char.Body)
+	init["BODY"] = strconv.Itoa(body)
 	init["MAP"] = strconv.Itoa(char.Position.Map)
 	init["POSITION"] = fmt.Sprintf("%d-%d-%d", char.Position.Map, char.Position.X, char.Position.Y)
 	init["HEADING"] = strconv.Itoa(int(char.Heading) + 1)
@@ -277,8 +333,9 @@ func (d *UserChrRepo) SaveCharacter(char *model.Character) error {
 	flags := data["FLAGS"]
 	flags["MUERTO"] = boolToIntString(char.Dead)
 	flags["ESCONDIDO"] = boolToIntString(char.Invisible)
-	flags["PARALIZADO"] = boolToIntString(char.Paralyzed)
+	flags["PARALIZADO"] = boolToIntString(char.HasEffect(model.EffectParalysis))
 	flags["NAVEGANDO"] = boolToIntString(char.Sailing)
+	flags["SILENCIADO"] = boolToIntString(char.Silenced)
 
 	attrs := data["ATRIBUTOS"]
 	attrs["AT1"] = strconv.Itoa(int(char.OriginalAttributes[model.Strength]))
@@ -305,6 +362,11 @@ func (d *UserChrRepo) SaveCharacter(char *model.Character) error {
 	stats["ORO"] = strconv.Itoa(char.Gold)
 	stats["BANCO"] = strconv.Itoa(char.BankGold)
 	stats["SKILLPTS"] = strconv.Itoa(char.SkillPoints)
+	stats["DUELOSGANADOS"] = strconv.Itoa(char.DuelsWon)
+	stats["DUELOSPERDIDOS"] = strconv.Itoa(char.DuelsLost)
+
+	if data["COUNTERS"] == nil { data["COUNTERS"] = make(map[string]string) }
+	data["COUNTERS"]["PENA"] = strconv.FormatInt(char.JailTime, 10)
 
 	// Skills
 	if data["SKILLS"] == nil { data["SKILLS"] = make(map[string]string) }
@@ -353,7 +415,7 @@ func (d *UserChrRepo) SaveCharacter(char *model.Character) error {
 	// Spells
 	if data["HECHIZOS"] == nil { data["HECHIZOS"] = make(map[string]string) }
 	h := data["HECHIZOS"]
-	for i := 0; i < 35; i++ {
+	for i := 0; i < model.MaxSpells; i++ {
 		key := fmt.Sprintf("H%d", i+1)
 		if i < len(char.Spells) {
 			h[key] = strconv.Itoa(char.Spells[i])
@@ -362,6 +424,40 @@ func (d *UserChrRepo) SaveCharacter(char *model.Character) error {
 		}
 	}
 
+	// Quests
+	q := make(map[string]string)
+	q["NUMQUESTS"] = strconv.Itoa(len(char.Quests))
+	for i, progress := range char.Quests {
+		parts := []string{strconv.Itoa(progress.QuestID)}
+		for _, p := range progress.Progress {
+			parts = append(parts, strconv.Itoa(p))
+		}
+		q[fmt.Sprintf("Q%d", i+1)] = strings.Join(parts, "-")
+	}
+	done := make([]string, 0, len(char.CompletedQuests))
+	for _, id := range char.CompletedQuests {
+		done = append(done, strconv.Itoa(id))
+	}
+	q["COMPLETADAS"] = strings.Join(done, "-")
+	data["QUESTS"] = q
+
+	// Effects keep the time they have left, as Type-RemainingMs-Magnitude (0 ms lasts until dispelled)
+	e := make(map[string]string)
+	i := 1
+	for _, effect := range char.ActiveEffects() {
+		if effect.Type == model.EffectMimicry {
+			continue
+		}
+		remaining := int64(0)
+		if !effect.Expires.IsZero() {
+			remaining = max(1, time.Until(effect.Expires).Milliseconds())
+		}
+		e[fmt.Sprintf("E%d", i)] = fmt.Sprintf("%d-%d-%d", effect.Type, remaining, effect.Magnitude)
+		i++
+	}
+	e["NUMEFECTOS"] = strconv.Itoa(i - 1)
+	data["EFECTOS"] = e
+
 	return d.writeINI(d.getFilePath(char.Name), data)
 }
 
@@ -429,7 +525,7 @@ func (d *UserChrRepo) writeINI(path string, data map[string]map[string]string) e
 
 	writer := bufio.NewWriter(file)
 	// We want some order if possible, but for simplicity let's just range
-	sections := []string{"INIT", "CONTACTO", "FLAGS", "ATRIBUTOS", "STATS", "SKILLS", "REP", "INVENTORY", "BANCOINVENTORY", "HECHIZOS"}
+	sections := []string{"INIT", "CONTACTO", "FLAGS", "ATRIBUTOS", "STATS", "SKILLS", "REP", "COUNTERS", "INVENTORY", "BANCOINVENTORY", "HECHIZOS", "QUESTS", "EFECTOS"}
 	for _, sec := range sections {
 		if inner, ok := data[sec]; ok {
 			fmt.Fprintf(writer, "[%s]\n", sec)
@@ -31,7 +31,7 @@ func (p *ChangeHeadingPacket) Handle(buffer *network.DataBuffer, connection prot
 	}
 
 	//if user's paralyzed can't head
-	if user.Paralyzed {
+	if user.HasEffect(model.EffectParalysis) {
 		return true, nil
 	}
 
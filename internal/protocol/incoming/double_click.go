@@ -12,13 +12,15 @@ import (
 )
 
 type DoubleClickPacket struct {
-	MapService    service.MapService
-	NpcService    service.NpcService
-	UserService   service.UserService
-	ObjectService service.ObjectService
-	AreaService   service.AreaService
-	BankService   service.BankService
-	SpellService  service.SpellService
+	MapService      service.MapService
+	NpcService      service.NpcService
+	UserService     service.UserService
+	ObjectService   service.ObjectService
+	AreaService     service.AreaService
+	BankService     service.BankService
+	CommerceService service.CommerceService
+	SpellService    service.SpellService
+	QuestService    service.QuestService
 }
 
 func (p *DoubleClickPacket) Handle(buffer *network.DataBuffer, connection protocol.Connection) (bool, error) {
@@ -91,6 +93,12 @@ func (p *DoubleClickPacket) Handle(buffer *network.DataBuffer, connection protoc
 			fmt.Printf("NPC DoubleClick: NPC %d has no description\n", npc.NPC.ID)
 		}
 
+		// Any NPC can be the one a talk objective asks for, only quest givers talk instead of
+		// doing what they usually do
+		if p.QuestService.TalkToNpc(user, npc) {
+			return true, nil
+		}
+
 		switch npc.NPC.Type {
 		case model.NTCommon:
 			if npc.NPC.CanTrade {
@@ -103,20 +111,7 @@ func (p *DoubleClickPacket) Handle(buffer *network.DataBuffer, connection protoc
 					return true, nil
 				}
 
-				user.TradingNPCIndex = npc.Index
-				connection.Send(&outgoing.CommerceInitPacket{})
-
-				// Send NPC Inventory
-				for i, slot := range npc.NPC.Inventory {
-					obj := p.ObjectService.GetObject(slot.ObjectID)
-					if obj != nil {
-						connection.Send(&outgoing.ChangeNpcInventorySlotPacket{
-							Slot:   byte(i + 1),
-							Object: obj,
-							Amount: slot.Amount,
-						})
-					}
-				}
+				p.CommerceService.OpenCommerce(user, npc)
 				return true, nil
 			}
 
@@ -136,6 +131,13 @@ func (p *DoubleClickPacket) Handle(buffer *network.DataBuffer, connection protoc
 			p.BankService.OpenBank(user)
 			return true, nil
 
+		case model.NTGambler:
+			if user.Dead {
+				connection.Send(&outgoing.ConsoleMessagePacket{Message: "¡Estás muerto!", Font: outgoing.INFO})
+				return true, nil
+			}
+			connection.Send(&outgoing.ConsoleMessagePacket{Message: "Para apostar escribe /APOSTAR <cantidad>.", Font: outgoing.INFO})
+
 		case model.NTHealer, model.NTHealerNewbie:
 			if dist > 10 {
 				connection.Send(&outgoing.ConsoleMessagePacket{Message: "El sacerdote no puede curarte debido a que estás demasiado lejos.", Font: outgoing.INFO})
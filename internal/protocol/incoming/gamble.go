@@ -0,0 +1,26 @@
+package incoming
+
+import (
+	"github.com/ao-go-server/internal/network"
+	"github.com/ao-go-server/internal/protocol"
+	"github.com/ao-go-server/internal/service"
+)
+
+type GamblePacket struct {
+	GamblingService service.GamblingService
+}
+
+func (p *GamblePacket) Handle(buffer *network.DataBuffer, connection protocol.Connection) (bool, error) {
+	amount, err := buffer.GetShort()
+	if err != nil {
+		return false, nil
+	}
+
+	char := connection.GetUser()
+	if char == nil {
+		return true, nil
+	}
+
+	p.GamblingService.Bet(char, int(amount))
+	return true, nil
+}
@@ -0,0 +1,27 @@
+package incoming
+
+import (
+	"github.com/ao-go-server/internal/network"
+	"github.com/ao-go-server/internal/protocol"
+	"github.com/ao-go-server/internal/service"
+)
+
+// GMRequestPacket is sent by /GM: the player asks a game master for help.
+type GMRequestPacket struct {
+	SosService service.SosService
+}
+
+func (p *GMRequestPacket) Handle(buffer *network.DataBuffer, connection protocol.Connection) (bool, error) {
+	message, err := buffer.GetUTF8String()
+	if err != nil {
+		return false, nil
+	}
+
+	char := connection.GetUser()
+	if char == nil {
+		return true, nil
+	}
+
+	p.SosService.Submit(char, message)
+	return true, nil
+}
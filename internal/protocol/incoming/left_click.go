@@ -182,12 +182,17 @@ func (p *LeftClickPacket) Handle(buffer *network.DataBuffer, connection protocol
 			targetUser := p.UserService.GetCharacterByIndex(targetCharIndex)
 			if targetUser != nil {
 				// Visibility check
-				if targetUser.Invisible && !user.Invisible { // simplified admin check
+				if (targetUser.Invisible && !user.Invisible) || (targetUser.AdminInvisible && !user.Privileges.IsGM()) { // simplified admin check
 					// Skip if invisible and viewer is not admin (assume user is not admin for now)
 				} else {
 					user.TargetUser = targetCharIndex
 					user.TargetNPC = 0
 
+					// A disguise gives nothing away about who wears it
+					if targetUser != user && targetUser.HasEffect(model.EffectMimicry) {
+						return true, nil
+					}
+
 					// Build status string
 					desc := targetUser.Description
 					if desc == "" {
@@ -37,6 +37,14 @@ func (p *PickUpPacket) Handle(buffer *network.DataBuffer, connection protocol.Co
 		return true, nil
 	}
 
+	if !worldObj.CanBePickedUpBy(char.Name) {
+		connection.Send(&outgoing.ConsoleMessagePacket{
+			Message: "Este objeto le pertenece a otro jugador, espera unos momentos.",
+			Font:    outgoing.INFO,
+		})
+		return true, nil
+	}
+
 	// Add to inventory
 	if char.Inventory.AddItem(worldObj.Object.ID, worldObj.Amount) {
 		// Sync inventory (simplified: update all slots or just the modified one?)
@@ -0,0 +1,53 @@
+package incoming
+
+import (
+	"github.com/ao-go-server/internal/network"
+	"github.com/ao-go-server/internal/protocol"
+	"github.com/ao-go-server/internal/service"
+)
+
+type SpellInfoPacket struct {
+	SpellService service.SpellService
+}
+
+func (p *SpellInfoPacket) Handle(buffer *network.DataBuffer, connection protocol.Connection) (bool, error) {
+	slot, err := buffer.Get()
+	if err != nil { return false, nil }
+
+	char := connection.GetUser()
+	if char == nil { return true, nil }
+	p.SpellService.SendSpellInfo(char, int(slot)-1)
+	return true, nil
+}
+
+type MoveSpellPacket struct {
+	SpellService service.SpellService
+}
+
+func (p *MoveSpellPacket) Handle(buffer *network.DataBuffer, connection protocol.Connection) (bool, error) {
+	if buffer.ReadableBytes() < 2 { // bool upwards + byte slot
+		return false, nil
+	}
+
+	upwards, _ := buffer.GetBoolean()
+	slot, _ := buffer.Get()
+
+	char := connection.GetUser()
+	if char == nil { return true, nil }
+	p.SpellService.MoveSpell(char, int(slot)-1, upwards)
+	return true, nil
+}
+
+type ForgetSpellPacket struct {
+	SpellService service.SpellService
+}
+
+func (p *ForgetSpellPacket) Handle(buffer *network.DataBuffer, connection protocol.Connection) (bool, error) {
+	slot, err := buffer.Get()
+	if err != nil { return false, nil }
+
+	char := connection.GetUser()
+	if char == nil { return true, nil }
+	p.SpellService.ForgetSpell(char, int(slot)-1)
+	return true, nil
+}
@@ -2,6 +2,9 @@ package incoming
 
 import (
 	"log/slog"
+	"strconv"
+	"strings"
+
 	"github.com/ao-go-server/internal/network"
 	"github.com/ao-go-server/internal/protocol"
 	"github.com/ao-go-server/internal/protocol/outgoing"
@@ -9,7 +12,9 @@ import (
 )
 
 type TalkPacket struct {
-	MessageService service.MessageService
+	MessageService  service.MessageService
+	GamblingService service.GamblingService
+	DuelService     service.DuelService
 }
 
 func (p *TalkPacket) Handle(buffer *network.DataBuffer, connection protocol.Connection) (bool, error) {
@@ -26,7 +31,35 @@ func (p *TalkPacket) Handle(buffer *network.DataBuffer, connection protocol.Conn
 
 	slog.Debug("TALK packet received", "user", char.Name, "message", message)
 
+	// Chat commands
+	if command, args, _ := strings.Cut(strings.TrimSpace(message), " "); strings.EqualFold(command, "/APOSTAR") {
+		amount, err := strconv.Atoi(strings.TrimSpace(args))
+		if err != nil {
+			connection.Send(&outgoing.ConsoleMessagePacket{Message: "Uso: /APOSTAR <cantidad>", Font: outgoing.INFO})
+			return true, nil
+		}
+		p.GamblingService.Bet(char, amount)
+		return true, nil
+	}
+
+	if target, ok := strings.CutPrefix(strings.ToUpper(message), "/DUELO "); ok {
+		p.DuelService.Challenge(char, strings.TrimSpace(target))
+		return true, nil
+	}
 
+	switch strings.ToUpper(strings.TrimSpace(message)) {
+	case "/ACEPTAR":
+		p.DuelService.Accept(char)
+		return true, nil
+	case "/RECHAZAR":
+		p.DuelService.Decline(char)
+		return true, nil
+	}
+
+	if char.Silenced {
+		connection.Send(&outgoing.ConsoleMessagePacket{Message: "Estás silenciado.", Font: outgoing.INFO})
+		return true, nil
+	}
 
 	p.MessageService.SendToArea(&outgoing.ChatOverHeadPacket{
 		Message:   message,
@@ -12,7 +12,8 @@ import (
 )
 
 type UseSkillPacket struct {
-	AreaService service.AreaService
+	AreaService  service.AreaService
+	SkillService service.SkillService
 }
 
 func (p *UseSkillPacket) Handle(buffer *network.DataBuffer, connection protocol.Connection) (bool, error) {
@@ -28,7 +29,7 @@ func (p *UseSkillPacket) Handle(buffer *network.DataBuffer, connection protocol.
 		fmt.Printf("User %s requested UseSkill for Skill ID: %d\n", user.Name, skillID)
 
 		switch skillID {
-		case model.Steal, model.Tame, model.Magic:
+		case model.Steal, model.Tame, model.Magic, model.Projectiles:
 			connection.Send(&outgoing.SkillRequestTargetPacket{Skill: skillID})
 
 		case model.Meditate:
@@ -53,9 +54,7 @@ func (p *UseSkillPacket) Handle(buffer *network.DataBuffer, connection protocol.
 			}
 
 		case model.Hiding:
-			// Toggle hiding
-			// TODO: Implement Hiding logic
-			connection.Send(&outgoing.ConsoleMessagePacket{Message: "Te ocultas...", Font: outgoing.INFO})
+			p.SkillService.Hide(user)
 
 		default:
 			// Others
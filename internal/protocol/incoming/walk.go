@@ -12,6 +12,7 @@ type WalkPacket struct {
 	MapService     service.MapService
 	MessageService service.MessageService
 	AreaService    service.AreaService // Still needed for Area logic in Handle
+	QuestService   service.QuestService
 }
 
 func (p *WalkPacket) Handle(buffer *network.DataBuffer, connection protocol.Connection) (bool, error) {
@@ -27,7 +28,16 @@ func (p *WalkPacket) Handle(buffer *network.DataBuffer, connection protocol.Conn
 		return true, nil // Not logged in
 	}
 
-	if char.Paralyzed {
+	if char.HasEffect(model.EffectParalysis) {
+		return true, nil
+	}
+
+	// The client doesn't know about stuns, put it back where it was
+	if char.HasEffect(model.EffectStun) {
+		connection.Send(&outgoing.PosUpdatePacket{
+			X: char.Position.X,
+			Y: char.Position.Y,
+		})
 		return true, nil
 	}
 
@@ -48,6 +58,12 @@ func (p *WalkPacket) Handle(buffer *network.DataBuffer, connection protocol.Conn
 		p.AreaService.BroadcastNearby(char, fxPacket)
 	}
 
+	if char.Hidden {
+		char.Hidden = false
+		p.MessageService.SendToArea(&outgoing.SetInvisiblePacket{CharIndex: char.CharIndex, Invisible: false}, char.Position)
+		connection.Send(&outgoing.ConsoleMessagePacket{Message: "¡Has vuelto a ser visible!", Font: outgoing.INFO})
+	}
+
 	oldPos := char.Position
 	newPos, success := p.MapService.MoveCharacterTo(char, heading)
 
@@ -96,6 +112,8 @@ func (p *WalkPacket) Handle(buffer *network.DataBuffer, connection protocol.Conn
 			p.AreaService.SendAreaState(char)
 			p.AreaService.BroadcastToArea(char.Position, &outgoing.CharacterCreatePacket{Character: char})
 
+			p.QuestService.OnCharacterMoved(char)
+
 			return true, nil
 		}
 	}
@@ -110,6 +128,7 @@ func (p *WalkPacket) Handle(buffer *network.DataBuffer, connection protocol.Conn
 	}
 
 	p.AreaService.NotifyMovement(char, oldPos)
+	p.QuestService.OnCharacterMoved(char)
 
 	// Confirm to client
 	connection.Send(&outgoing.PosUpdatePacket{
@@ -28,6 +28,11 @@ func (p *WhisperPacket) Handle(buffer *network.DataBuffer, connection protocol.C
 		return true, nil
 	}
 
+	if char.Silenced {
+		connection.Send(&outgoing.ConsoleMessagePacket{Message: "Estás silenciado.", Font: outgoing.INFO})
+		return true, nil
+	}
+
 	targetChar := p.UserService.GetCharacterByIndex(targetIndex)
 	if targetChar != nil {
 		targetConn := p.UserService.GetConnection(targetChar)
@@ -28,6 +28,11 @@ func (p *YellPacket) Handle(buffer *network.DataBuffer, connection protocol.Conn
 
 
 
+	if char.Silenced {
+		connection.Send(&outgoing.ConsoleMessagePacket{Message: "Estás silenciado.", Font: outgoing.INFO})
+		return true, nil
+	}
+
 	p.MessageService.SendToArea(&outgoing.ChatOverHeadPacket{
 		Message:   message,
 		CharIndex: char.CharIndex,
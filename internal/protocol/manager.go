@@ -54,7 +54,10 @@ const (
 	CP_BankExtractItem ClientPackets = 41
 	CP_CommerceSell ClientPackets = 42
 	CP_BankDeposit ClientPackets = 43
+	CP_MoveSpell ClientPackets = 45
 
+	CP_GMRequest ClientPackets = 102
+	CP_Gamble ClientPackets = 108
 	CP_ExtractGold ClientPackets = 111
 	CP_DepositGold ClientPackets = 112
 
@@ -70,6 +73,7 @@ const (
 	CP_Meditate ClientPackets = 79
 	CP_Resurrect ClientPackets = 80
 	CP_GMCommands ClientPackets = 122
+	CP_ForgetSpell ClientPackets = 123
 	CP_Ping ClientPackets = 160
 )
 
@@ -308,6 +312,14 @@ func GetOutgoingPacketID(packet OutgoingPacket) (ServerPackets, error) {
 		return SP_ToggleNavigate, nil
 	case *outgoing.PongPacket:
 		return SP_Pong, nil
+	case *outgoing.SetInvisiblePacket:
+		return SP_SetInvisible, nil
+	case *outgoing.ShowSosFormPacket:
+		return SP_ShowSosForm, nil
+	case *outgoing.ShowMotdEditionFormPacket:
+		return SP_ShowMotdEditionForm, nil
+	case *outgoing.SpawnListPacket:
+		return SP_SpawnList, nil
 	}
 	return 0, fmt.Errorf("unknown outgoing packet type")
 }
@@ -24,7 +24,12 @@ func (p *CharacterCreatePacket) Write(buffer *network.DataBuffer) error {
 	buffer.PutShort(0) // Fx ID
 	buffer.PutShort(0) // Fx Loops
 	
-	buffer.PutUTF8String(p.Character.Name) // Java uses putUnicodeString which is UTF-8 with 2 byte len
+	// Disguised characters go nameless
+	name := p.Character.Name
+	if p.Character.HasEffect(model.EffectMimicry) {
+		name = ""
+	}
+	buffer.PutUTF8String(name) // Java uses putUnicodeString which is UTF-8 with 2 byte len
 	buffer.Put(0) // Nick Color
 	buffer.Put(byte(p.Character.Privileges)) // Privileges Flags
 	
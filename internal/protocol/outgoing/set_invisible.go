@@ -0,0 +1,16 @@
+package outgoing
+
+import (
+	"github.com/ao-go-server/internal/network"
+)
+
+type SetInvisiblePacket struct {
+	CharIndex int16
+	Invisible bool
+}
+
+func (p *SetInvisiblePacket) Write(buffer *network.DataBuffer) error {
+	buffer.PutShort(p.CharIndex)
+	buffer.PutBoolean(p.Invisible)
+	return nil
+}
@@ -0,0 +1,15 @@
+package outgoing
+
+import (
+	"github.com/ao-go-server/internal/network"
+)
+
+// ShowMotdEditionFormPacket opens the MOTD editor with the current text, lines separated by CRLF.
+type ShowMotdEditionFormPacket struct {
+	Text string
+}
+
+func (p *ShowMotdEditionFormPacket) Write(buffer *network.DataBuffer) error {
+	buffer.PutUTF8String(p.Text)
+	return nil
+}
@@ -0,0 +1,18 @@
+package outgoing
+
+import (
+	"strings"
+
+	"github.com/ao-go-server/internal/network"
+)
+
+// ShowSosFormPacket opens the GM help-request form. Entries are sent as a single
+// string separated by null characters.
+type ShowSosFormPacket struct {
+	Entries []string
+}
+
+func (p *ShowSosFormPacket) Write(buffer *network.DataBuffer) error {
+	buffer.PutUTF8String(strings.Join(p.Entries, "\x00"))
+	return nil
+}
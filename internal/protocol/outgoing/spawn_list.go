@@ -0,0 +1,18 @@
+package outgoing
+
+import (
+	"strings"
+
+	"github.com/ao-go-server/internal/network"
+)
+
+// SpawnListPacket lists the creatures a GM can summon, as a single string separated by
+// null characters. The client answers with the 1-based position of the chosen one.
+type SpawnListPacket struct {
+	Names []string
+}
+
+func (p *SpawnListPacket) Write(buffer *network.DataBuffer) error {
+	buffer.PutUTF8String(strings.Join(p.Names, "\x00"))
+	return nil
+}
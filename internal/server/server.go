@@ -41,6 +41,10 @@ type Server struct {
 
         aiService      service.AiService
 
+        sosService     service.SosService
+
+        motdService    service.MotdService
+
         config         *config.Config
 
         globalBalance  *model.GlobalBalanceConfig
@@ -91,10 +95,6 @@ func NewServer(addr string, resourcesPath string) *Server {
 
         indexManager := service.NewCharacterIndexManager()
 
-        npcRepo := persistence.NewNpcDatRepo(filepath.Join(res, projectCfg.Project.Paths.NpcsDat))
-
-        npcService := service.NewNpcServiceImpl(npcRepo, indexManager)
-
 
 
         cityRepo := persistence.NewCityDatRepo(filepath.Join(res, projectCfg.Project.Paths.CitiesDat))
@@ -105,7 +105,7 @@ func NewServer(addr string, resourcesPath string) *Server {
 
         balanceRepo := persistence.NewBalanceYamlRepo(filepath.Join(cfgPath, "balances.yaml"))
 
-        archetypeMods, _, globalBalance, err := balanceRepo.Load()
+        archetypeMods, raceMods, globalBalance, err := balanceRepo.Load()
 
         if err != nil {
 
@@ -119,6 +119,12 @@ func NewServer(addr string, resourcesPath string) *Server {
 
 
 
+        npcRepo := persistence.NewNpcDatRepo(filepath.Join(res, projectCfg.Project.Paths.NpcsDat))
+
+        npcService := service.NewNpcServiceImpl(npcRepo, indexManager, globalBalance)
+
+
+
         bodyService := service.NewCharacterBodyServiceImpl(projectCfg)
 
         userService := service.NewUserServiceImpl(bodyService)
@@ -143,23 +149,35 @@ func NewServer(addr string, resourcesPath string) *Server {
 
         messageService := service.NewMessageServiceImpl(userService, areaService, mapService, objectService)
 
+        effectService := service.NewEffectServiceImpl(userService, messageService)
+
         trainingService := service.NewTrainingServiceImpl(messageService, userService, archetypeMods, globalBalance)
 
 
 
+        questRepo := persistence.NewQuestDatRepo(filepath.Join(res, projectCfg.Project.Paths.QuestsDat))
+
+        questService := service.NewQuestServiceImpl(questRepo, userService, npcService, messageService, objectService, trainingService)
+
+        duelService := service.NewDuelServiceImpl(userService, messageService, mapService, effectService, globalBalance)
+
+        lootService := service.NewLootServiceImpl(objectService, mapService, messageService, userService, cfg, globalBalance)
+
+
+
         spellRepo := persistence.NewSpellDatRepo(filepath.Join(res, "data/hechizos.dat"))
 
-        spellService := service.NewSpellServiceImpl(spellRepo, userService, npcService, messageService, objectService, intervalService, trainingService, areaService, cfg)
+        spellService := service.NewSpellServiceImpl(spellRepo, userService, npcService, messageService, objectService, intervalService, trainingService, areaService, questService, duelService, lootService, effectService, cfg, globalBalance)
 
 
 
-        resourceManager := service.NewResourceManagerImpl(objectService, npcService, mapService, spellService, cityService)
+        resourceManager := service.NewResourceManagerImpl(objectService, npcService, mapService, spellService, cityService, questService)
 
         resourceManager.LoadAll()
 
 
 
-                        combatService := service.NewCombatServiceImpl(messageService, objectService, npcService, mapService, combatFormulas, intervalService, trainingService, cfg)
+                        combatService := service.NewCombatServiceImpl(messageService, objectService, npcService, mapService, combatFormulas, intervalService, trainingService, questService, duelService, lootService, effectService, cfg, globalBalance)
 
 
 
@@ -167,7 +185,7 @@ func NewServer(addr string, resourcesPath string) *Server {
 
 
 
-                        skillService := service.NewSkillServiceImpl(mapService, objectService, messageService, userService, npcService, spellService, intervalService)
+                        skillService := service.NewSkillServiceImpl(mapService, objectService, messageService, userService, npcService, spellService, combatService, intervalService, trainingService)
 
 
 
@@ -175,6 +193,10 @@ func NewServer(addr string, resourcesPath string) *Server {
 
 
 
+                        commerceService := service.NewCommerceServiceImpl(npcService, objectService, messageService, userService, globalBalance)
+
+
+
                 
 
 
@@ -183,11 +205,39 @@ func NewServer(addr string, resourcesPath string) *Server {
 
 
 
-                        loginService := service.NewLoginServiceImpl(userRepo, cfg, projectCfg, userService, mapService, bodyService, indexManager, messageService, objectService, cityService, spellService)
+                        motdRepo := persistence.NewMotdDatRepo(filepath.Join(res, projectCfg.Project.Paths.MotdDat))
+
+
+
+                        motdService := service.NewMotdServiceImpl(motdRepo, userService, messageService)
+
+
+
+                        if err := motdService.LoadMotd(); err != nil {
+
+                                slog.Error("Failed to load MOTD", "error", err)
+
+                        }
 
 
 
-                        itemActionService := service.NewItemActionServiceImpl(objectService, messageService, intervalService, bodyService, spellService)
+                        banIPRepo := persistence.NewBanIPDatRepo(filepath.Join(res, projectCfg.Project.Paths.BanIPsDat))
+
+
+
+                        loginService := service.NewLoginServiceImpl(userRepo, banIPRepo, cfg, projectCfg, userService, mapService, bodyService, indexManager, messageService, objectService, cityService, spellService, duelService, motdService, raceMods, archetypeMods)
+
+
+
+                        if err := loginService.LoadBannedIPs(); err != nil {
+
+                                slog.Error("Failed to load banned IPs", "error", err)
+
+                        }
+
+
+
+                        itemActionService := service.NewItemActionServiceImpl(objectService, messageService, intervalService, bodyService, spellService, effectService)
 
 
 
@@ -195,7 +245,7 @@ func NewServer(addr string, resourcesPath string) *Server {
 
 
 
-                        timedEventsService := service.NewTimedEventsServiceImpl(userService, messageService, loginService, cfg, globalBalance)
+                        timedEventsService := service.NewTimedEventsServiceImpl(userService, messageService, loginService, commerceService, trainingService, effectService, duelService, cfg, globalBalance)
 
 
 
@@ -219,7 +269,25 @@ func NewServer(addr string, resourcesPath string) *Server {
 
 
 
-                        gmService := service.NewGmServiceImpl(userService, mapService, messageService, loginService)
+                        sosRepo := persistence.NewSosDatRepo(filepath.Join(res, projectCfg.Project.Paths.SosDat))
+
+
+
+                        sosService := service.NewSosServiceImpl(sosRepo, userService, messageService)
+
+
+
+                        if err := sosService.LoadTickets(); err != nil {
+
+                                slog.Error("Failed to load SOS tickets", "error", err)
+
+                        }
+
+
+
+                        gmService := service.NewGmServiceImpl(userService, mapService, messageService, loginService, npcService, objectService, sosService, motdService)
+
+                        gamblingService := service.NewGamblingServiceImpl(npcService, messageService, globalBalance)
 
 
 
@@ -247,7 +315,7 @@ func NewServer(addr string, resourcesPath string) *Server {
 
         m.RegisterHandler(protocol.CP_ThrowDice, &incoming.ThrowDicesPacket{})
 
-        m.RegisterHandler(protocol.CP_Walk, &incoming.WalkPacket{MapService: mapService, AreaService: areaService, MessageService: messageService})
+        m.RegisterHandler(protocol.CP_Walk, &incoming.WalkPacket{MapService: mapService, AreaService: areaService, MessageService: messageService, QuestService: questService})
 
         m.RegisterHandler(protocol.CP_RequestPositionUpdate, &incoming.RequestPositionUpdatePacket{})
 
@@ -259,7 +327,7 @@ func NewServer(addr string, resourcesPath string) *Server {
 
         m.RegisterHandler(protocol.CP_RequestSkills, &incoming.RequestSkillsPacket{})
 
-        m.RegisterHandler(protocol.CP_Talk, &incoming.TalkPacket{MessageService: messageService})
+        m.RegisterHandler(protocol.CP_Talk, &incoming.TalkPacket{MessageService: messageService, GamblingService: gamblingService, DuelService: duelService})
 
         m.RegisterHandler(protocol.CP_Yell, &incoming.YellPacket{MessageService: messageService})
 
@@ -278,6 +346,9 @@ func NewServer(addr string, resourcesPath string) *Server {
         m.RegisterHandler(protocol.CP_Drop, &incoming.DropPacket{MapService: mapService, MessageService: messageService, ObjectService: objectService})
 
         m.RegisterHandler(protocol.CP_CastSpell, &incoming.CastSpellPacket{MapService: mapService, SpellService: spellService})
+        m.RegisterHandler(protocol.CP_SpellInfo, &incoming.SpellInfoPacket{SpellService: spellService})
+        m.RegisterHandler(protocol.CP_MoveSpell, &incoming.MoveSpellPacket{SpellService: spellService})
+        m.RegisterHandler(protocol.CP_ForgetSpell, &incoming.ForgetSpellPacket{SpellService: spellService})
 
         m.RegisterHandler(protocol.CP_LeftClick, &incoming.LeftClickPacket{MapService: mapService, NpcService: npcService, UserService: userService, ObjectService: objectService, AreaService: areaService})
 
@@ -289,9 +360,9 @@ func NewServer(addr string, resourcesPath string) *Server {
 
         m.RegisterHandler(protocol.CP_ChangeHeading, &incoming.ChangeHeadingPacket{AreaService: areaService})
 
-        m.RegisterHandler(protocol.CP_Double_Click, &incoming.DoubleClickPacket{MapService: mapService, NpcService: npcService, UserService: userService, ObjectService: objectService, AreaService: areaService, BankService: bankService, SpellService: spellService})
+        m.RegisterHandler(protocol.CP_Double_Click, &incoming.DoubleClickPacket{MapService: mapService, NpcService: npcService, UserService: userService, ObjectService: objectService, AreaService: areaService, BankService: bankService, CommerceService: commerceService, SpellService: spellService, QuestService: questService})
 
-        m.RegisterHandler(protocol.CP_Work, &incoming.UseSkillPacket{AreaService: areaService})
+        m.RegisterHandler(protocol.CP_Work, &incoming.UseSkillPacket{AreaService: areaService, SkillService: skillService})
 
         m.RegisterHandler(protocol.CP_WorkLeftClick, &incoming.UseSkillClickPacket{SkillService: skillService})
 
@@ -299,11 +370,11 @@ func NewServer(addr string, resourcesPath string) *Server {
 
 
 
-        m.RegisterHandler(protocol.CP_CommerceEnd, &incoming.CommerceEndPacket{})
+        m.RegisterHandler(protocol.CP_CommerceEnd, &incoming.CommerceEndPacket{CommerceService: commerceService})
 
-        m.RegisterHandler(protocol.CP_CommerceBuy, &incoming.CommerceBuyPacket{NpcService: npcService, ObjectService: objectService, MessageService: messageService})
+        m.RegisterHandler(protocol.CP_CommerceBuy, &incoming.CommerceBuyPacket{CommerceService: commerceService})
 
-        m.RegisterHandler(protocol.CP_CommerceSell, &incoming.CommerceSellPacket{NpcService: npcService, ObjectService: objectService, MessageService: messageService})
+        m.RegisterHandler(protocol.CP_CommerceSell, &incoming.CommerceSellPacket{CommerceService: commerceService})
 
 
 
@@ -316,6 +387,11 @@ func NewServer(addr string, resourcesPath string) *Server {
         m.RegisterHandler(protocol.CP_ExtractGold, &incoming.ExtractGoldPacket{BankService: bankService})
 
         m.RegisterHandler(protocol.CP_DepositGold, &incoming.DepositGoldPacket{BankService: bankService})
+        m.RegisterHandler(protocol.CP_Gamble, &incoming.GamblePacket{GamblingService: gamblingService})
+
+
+
+        m.RegisterHandler(protocol.CP_GMRequest, &incoming.GMRequestPacket{SosService: sosService})
 
 
 
@@ -339,6 +415,10 @@ func NewServer(addr string, resourcesPath string) *Server {
 
                 aiService:      aiService,
 
+                sosService:     sosService,
+
+                motdService:    motdService,
+
                 config:         cfg,
 
                 globalBalance:  globalBalance,
@@ -371,7 +451,7 @@ func (s *Server) Start() error {
 
         configPath := filepath.Join(s.resourcesPath, "config_yaml", "server.yaml")
 
-        adminAPI := api.NewAdminAPI(s.mapService, s.userService, s.userRepo, s.loginService, s.messageService, s.npcService, s.aiService, s.config, s.globalBalance, configPath)
+        adminAPI := api.NewAdminAPI(s.mapService, s.userService, s.userRepo, s.loginService, s.messageService, s.npcService, s.aiService, s.sosService, s.motdService, s.config, s.globalBalance, configPath)
 
         go adminAPI.Start(":7667")
 	if err := os.WriteFile("server.pid", []byte(fmt.Sprintf("%d", os.Getpid())), 0644); err != nil {
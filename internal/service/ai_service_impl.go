@@ -6,6 +6,27 @@ import (
 	"time"
 
 	"github.com/ao-go-server/internal/model"
+	"github.com/ao-go-server/internal/protocol/outgoing"
+)
+
+// NpcOriginLeash is how far wandering fixed-origin NPCs may stray from PosOrig.
+const NpcOriginLeash = 5
+
+// NpcTargetRange is how far NPCs look for, and keep chasing, other NPCs to fight.
+const NpcTargetRange = 10
+
+// PetGuardRange is how close to its owner a creature must be for a pet to go after it.
+const PetGuardRange = 5
+
+const (
+	// PathMaxNodes caps the tiles a single path search may expand
+	PathMaxNodes = 1500
+	// PathNodesPerTick is the search budget shared by every NPC in one AI tick
+	PathNodesPerTick = 6000
+	// PathRecalcDistance is how far a target may move from the end of a cached path before searching again
+	PathRecalcDistance = 2
+	// PathRetryDelay is how long an NPC waits before searching again after failing to find a path
+	PathRetryDelay = time.Second
 )
 
 type AiServiceImpl struct {
@@ -19,6 +40,7 @@ type AiServiceImpl struct {
 	globalBalance  *model.GlobalBalanceConfig
 	stopChan       chan struct{}
 	ticks          uint64
+	pathBudget     int
 	enabled        bool
 }
 
@@ -65,6 +87,8 @@ func (s *AiServiceImpl) aiLoop() {
 				s.ticks++
 				s.processNpcs()
 			}
+			s.npcService.ProcessRespawns(s.mapService, s.areaService)
+			s.spellService.ProcessFields(time.Now())
 		case <-s.stopChan:
 			return
 		}
@@ -72,6 +96,7 @@ func (s *AiServiceImpl) aiLoop() {
 }
 
 func (s *AiServiceImpl) processNpcs() {
+	s.pathBudget = PathNodesPerTick
 	npcs := s.npcService.GetWorldNpcs()
 	for _, npc := range npcs {
 		s.handleNpcAI(npc)
@@ -85,6 +110,16 @@ func (s *AiServiceImpl) handleNpcAI(npc *model.WorldNPC) {
 		npc.Immobilized = false
 	}
 
+	// Stunned creatures neither move nor fight
+	if time.Now().Before(npc.StunnedUntil) {
+		return
+	}
+
+	if npc.OwnerIndex != 0 {
+		s.mascotaAI(npc)
+		return
+	}
+
 	// 1. Hostility/Attack Logic - Handled by intervals in CombatService
 	if npc.OwnerIndex == 0 {
 		if npc.NPC.Type == model.NTGuard {
@@ -96,7 +131,15 @@ func (s *AiServiceImpl) handleNpcAI(npc *model.WorldNPC) {
 		}
 	}
 
-	// 2. Movement Logic
+	// 2. Fights against other NPCs
+	if npc.TargetNpc == 0 {
+		npc.TargetNpc = s.buscarNpcObjetivo(npc)
+	}
+	if s.luchaContraNpc(npc) {
+		return
+	}
+
+	// 3. Movement Logic
 	if npc.Paralyzed || npc.Immobilized || time.Since(npc.LastMovement).Milliseconds() < s.globalBalance.NPCIntervalMove {
 		return
 	}
@@ -114,8 +157,11 @@ func (s *AiServiceImpl) handleNpcAI(npc *model.WorldNPC) {
 				moved = s.persigueCiudadano(npc)
 			}
 		}
+		if !moved {
+			moved = s.volverAOrigen(npc, NpcOriginLeash)
+		}
 
-	case model.MovementHostile:
+	case model.MovementHostile, model.MovementPathfinding:
 		moved = s.irUsuarioCercano(npc)
 
 	case model.MovementDefense:
@@ -123,6 +169,9 @@ func (s *AiServiceImpl) handleNpcAI(npc *model.WorldNPC) {
 
 	case model.MovementGuardAttackCriminals:
 		moved = s.persigueCriminal(npc)
+		if !moved {
+			moved = s.volverAOrigen(npc, 0)
+		}
 
 	case model.MovementFollowOwner:
 		moved = s.seguirAmo(npc)
@@ -157,39 +206,90 @@ func (s *AiServiceImpl) moveNpc(npc *model.WorldNPC, heading model.Heading) bool
 		newPos.X--
 	}
 
-	// Boundary checks
-	if !s.mapService.IsInPlayableArea(int(newPos.X), int(newPos.Y)) {
+	gameMap := s.mapService.GetMap(newPos.Map)
+	if gameMap == nil || !s.isWalkable(gameMap, int(newPos.X), int(newPos.Y)) {
 		return false
 	}
 
-	//Valid position check
-	if s.mapService.IsInvalidPosition(
-		model.Position{
-			X:   newPos.X,
-			Y:   newPos.Y,
-			Map: npc.Position.Map,
-		}) {
+	tile := gameMap.GetTile(int(newPos.X), int(newPos.Y))
+	if tile.Character != nil || tile.NPC != nil {
 		return false
 	}
 
-	// Check if tile is blocked
-	gameMap := s.mapService.GetMap(newPos.Map)
-	if gameMap == nil {
+	return s.npcService.MoveNpc(npc, newPos, heading, s.mapService, s.areaService)
+}
+
+// isWalkable reports whether NPCs may ever stand on the tile, ignoring who is standing there now.
+func (s *AiServiceImpl) isWalkable(m *model.Map, x, y int) bool {
+	if !s.mapService.IsInPlayableArea(x, y) {
 		return false
 	}
 
-	tile := gameMap.GetTile(int(newPos.X), int(newPos.Y))
-	if tile.Blocked || tile.Character != nil || tile.NPC != nil {
+	tile := m.GetTile(x, y)
+	if tile.Blocked || tile.IsLava || tile.Trigger == model.TriggerInvalidPosition {
 		return false
 	}
 
-	// Water check: NPCs cannot walk on water unless there is a bridge
+	// NPCs cannot walk on water unless there is a bridge
 	hasBridge := tile.Layer2 > 0 || tile.Layer3 > 0
-	if tile.IsWater && !hasBridge {
+	return !tile.IsWater || hasBridge
+}
+
+// moverHacia moves the NPC one step along a path towards dest. Paths are cached on the NPC
+// and only searched again once they run out, get blocked or the target wanders off. When
+// this tick's search budget is spent the NPC falls back to a straight step.
+func (s *AiServiceImpl) moverHacia(npc *model.WorldNPC, dest model.Position) bool {
+	m := s.mapService.GetMap(npc.Position.Map)
+	if m == nil || dest.Map != npc.Position.Map {
 		return false
 	}
 
-	return s.npcService.MoveNpc(npc, newPos, heading, s.mapService, s.areaService)
+	for len(npc.Path) > 0 && npc.Path[0] == npc.Position {
+		npc.Path = npc.Path[1:]
+	}
+
+	if len(npc.Path) == 0 || npc.Path[0].GetDistance(npc.Position) != 1 || npc.PathDest.GetDistance(dest) > PathRecalcDistance {
+		npc.Path = nil
+		if npc.PathDest == dest && time.Now().Before(npc.PathRetry) {
+			return false
+		}
+		if s.pathBudget <= 0 {
+			return s.moveNpc(npc, s.findDirection(npc.Position, dest))
+		}
+
+		path, expanded := m.FindPath(npc.Position, dest, min(PathMaxNodes, s.pathBudget), func(x, y int) bool {
+			if !s.isWalkable(m, x, y) {
+				return false
+			}
+			tile := m.GetTile(x, y)
+			return tile.Character == nil && tile.NPC == nil
+		})
+		s.pathBudget -= expanded
+		npc.PathDest = dest
+
+		if path == nil {
+			npc.PathRetry = time.Now().Add(PathRetryDelay)
+			return false
+		}
+		npc.Path = path
+	}
+
+	// The last step is the target's own tile, callers attack from next to it
+	next := npc.Path[0]
+	if next == dest {
+		if tile := m.GetTile(int(dest.X), int(dest.Y)); tile.Character != nil || tile.NPC != nil {
+			return false
+		}
+	}
+
+	if s.moveNpc(npc, s.findDirection(npc.Position, next)) {
+		npc.Path = npc.Path[1:]
+		return true
+	}
+
+	// Someone stepped in the way
+	npc.Path = nil
+	return false
 }
 
 func (s *AiServiceImpl) guardiasAI(npc *model.WorldNPC, delCaos bool) {
@@ -284,7 +384,7 @@ func (s *AiServiceImpl) irUsuarioCercano(npc *model.WorldNPC) bool {
 	minDist := 15 // Range of vision
 
 	for _, user := range s.userService.GetLoggedCharacters() {
-		if user.Position.Map != npc.Position.Map || user.Dead {
+		if user.Position.Map != npc.Position.Map || user.Dead || user.AdminInvisible {
 			continue
 		}
 		dist := npc.Position.GetDistance(user.Position)
@@ -300,8 +400,7 @@ func (s *AiServiceImpl) irUsuarioCercano(npc *model.WorldNPC) bool {
 				return false // Attacking isn't moving
 			}
 		} else {
-			heading := s.findDirection(npc.Position, closestUser.Position)
-			return s.moveNpc(npc, heading)
+			return s.moverHacia(npc, closestUser.Position)
 		}
 	} else if rand.Intn(10) == 0 {
 		return s.moveRandomly(npc)
@@ -329,8 +428,7 @@ func (s *AiServiceImpl) persigueCriminal(npc *model.WorldNPC) bool {
 	}
 
 	if target != nil {
-		heading := s.findDirection(npc.Position, target.Position)
-		return s.moveNpc(npc, heading)
+		return s.moverHacia(npc, target.Position)
 	}
 	return false
 }
@@ -355,8 +453,7 @@ func (s *AiServiceImpl) persigueCiudadano(npc *model.WorldNPC) bool {
 	}
 
 	if target != nil {
-		heading := s.findDirection(npc.Position, target.Position)
-		return s.moveNpc(npc, heading)
+		return s.moverHacia(npc, target.Position)
 	}
 	return false
 }
@@ -385,8 +482,7 @@ func (s *AiServiceImpl) seguirAgresor(npc *model.WorldNPC) bool {
 			return false
 		}
 	} else if !npc.Immobilized {
-		heading := s.findDirection(npc.Position, target.Position)
-		return s.moveNpc(npc, heading)
+		return s.moverHacia(npc, target.Position)
 	}
 	return false
 }
@@ -399,12 +495,126 @@ func (s *AiServiceImpl) seguirAmo(npc *model.WorldNPC) bool {
 
 	dist := npc.Position.GetDistance(owner.Position)
 	if dist > 3 && dist < 15 {
-		heading := s.findDirection(npc.Position, owner.Position)
-		return s.moveNpc(npc, heading)
+		return s.moverHacia(npc, owner.Position)
 	}
 	return false
 }
 
+// mascotaAI drives summoned and tamed creatures: they defend their owner from nearby
+// creatures, join the owner's fights and otherwise follow them around. Pets whose owner
+// is gone, dead or on another map are dismissed.
+func (s *AiServiceImpl) mascotaAI(npc *model.WorldNPC) {
+	owner := s.userService.GetCharacterByIndex(int16(npc.OwnerIndex))
+	if owner == nil || owner.Dead || owner.Position.Map != npc.Position.Map {
+		s.liberarMascota(npc)
+		return
+	}
+
+	if npc.TargetNpc == 0 {
+		npc.TargetNpc = s.npcMasCercano(npc, owner.Position, PetGuardRange, func(other *model.WorldNPC) bool {
+			return other.OwnerIndex == 0 && other.NPC.Hostile
+		})
+	}
+	if s.luchaContraNpc(npc) {
+		return
+	}
+
+	if npc.Paralyzed || npc.Immobilized || time.Since(npc.LastMovement).Milliseconds() < s.globalBalance.NPCIntervalMove {
+		return
+	}
+	if s.seguirAmo(npc) {
+		npc.LastMovement = time.Now()
+	}
+}
+
+func (s *AiServiceImpl) liberarMascota(npc *model.WorldNPC) {
+	npc.Respawn = false
+	s.areaService.BroadcastToArea(npc.Position, &outgoing.CharacterRemovePacket{CharIndex: npc.Index})
+	s.npcService.RemoveNPC(npc, s.mapService)
+}
+
+// buscarNpcObjetivo picks a creature for the NPC to fight: NPCs with MovementAttackNpc go
+// after pets and hostile creatures of another kind, and guards go after hostile creatures
+// that walk into their city.
+func (s *AiServiceImpl) buscarNpcObjetivo(npc *model.WorldNPC) int16 {
+	switch {
+	case model.MovementType(npc.NPC.Movement) == model.MovementAttackNpc:
+		return s.npcMasCercano(npc, npc.Position, NpcTargetRange, func(other *model.WorldNPC) bool {
+			return other.OwnerIndex != 0 || (other.NPC.Hostile && other.NPC.ID != npc.NPC.ID)
+		})
+	case (npc.NPC.Type == model.NTGuard || npc.NPC.Type == model.NTGuardCaos) && s.enCiudad(npc.Position):
+		return s.npcMasCercano(npc, npc.Position, NpcTargetRange, func(other *model.WorldNPC) bool {
+			return other.OwnerIndex == 0 && other.NPC.Hostile && s.enCiudad(other.Position)
+		})
+	}
+	return 0
+}
+
+// enCiudad reports whether pos belongs to a city: a safe zone or a map where PvP is not allowed.
+func (s *AiServiceImpl) enCiudad(pos model.Position) bool {
+	return s.mapService.IsSafeZone(pos) || !s.mapService.IsPkMap(pos.Map)
+}
+
+func (s *AiServiceImpl) npcMasCercano(npc *model.WorldNPC, from model.Position, maxDist int, accept func(*model.WorldNPC) bool) int16 {
+	var closest int16
+	minDist := maxDist + 1
+
+	s.mapService.ForEachNpc(npc.Position.Map, func(other *model.WorldNPC) {
+		if other == npc || other.HP <= 0 || !accept(other) {
+			return
+		}
+		if dist := from.GetDistance(other.Position); dist < minDist {
+			closest = other.Index
+			minDist = dist
+		}
+	})
+	return closest
+}
+
+// luchaContraNpc attacks or chases the NPC's current NPC target. It returns false when
+// there is nothing to fight so the regular AI can run.
+func (s *AiServiceImpl) luchaContraNpc(npc *model.WorldNPC) bool {
+	if npc.TargetNpc == 0 {
+		return false
+	}
+
+	target := s.npcService.GetWorldNpcByIndex(npc.TargetNpc)
+	if target == nil || target == npc || target.HP <= 0 || target.Position.Map != npc.Position.Map ||
+		npc.Position.GetDistance(target.Position) > NpcTargetRange {
+		npc.TargetNpc = 0
+		return false
+	}
+
+	if npc.Position.GetDistance(target.Position) <= 1 {
+		heading := s.findDirection(npc.Position, target.Position)
+		if npc.Heading != heading {
+			s.npcService.ChangeNpcHeading(npc, heading, s.areaService)
+		}
+		s.combatService.NpcAtacaNpc(npc, target)
+		return true
+	}
+
+	if npc.Paralyzed || npc.Immobilized || time.Since(npc.LastMovement).Milliseconds() < s.globalBalance.NPCIntervalMove {
+		return true
+	}
+	if s.moverHacia(npc, target.Position) {
+		npc.LastMovement = time.Now()
+	}
+	return true
+}
+
+// volverAOrigen walks fixed-origin NPCs back to their PosOrig once they are more than leash tiles away.
+func (s *AiServiceImpl) volverAOrigen(npc *model.WorldNPC, leash int) bool {
+	if !npc.NPC.FixedOrigin || npc.Immobilized || npc.Position.Map != npc.Origin.Map {
+		return false
+	}
+	if npc.Position.GetDistance(npc.Origin) <= leash {
+		return false
+	}
+
+	return s.moverHacia(npc, npc.Origin)
+}
+
 func (s *AiServiceImpl) aiNpcObjeto(npc *model.WorldNPC) {
 	// NPC objects don't move, they just attack nearby users
 	s.hostilMalvadoAI(npc)
@@ -141,10 +141,10 @@ func (s *AreaServiceImpl) NotifyMovement(char *model.Character, oldPos model.Pos
 			// He wasn't in range but now he is.
 			// I should see him appear, and he should see me appear.
 			if connOther != nil {
-				connOther.Send(&outgoing.CharacterCreatePacket{Character: char})
+				sendCharacterCreate(connOther, char)
 			}
 			if connMe != nil {
-				connMe.Send(&outgoing.CharacterCreatePacket{Character: other})
+				sendCharacterCreate(connMe, other)
 			}
 		}
 	})
@@ -226,7 +226,7 @@ func (s *AreaServiceImpl) SendAreaState(char *model.Character) {
 		for _, other := range gameMap.GetCharacters() {
 			if other != char {
 				if s.InRange(char.Position, other.Position) {
-					conn.Send(&outgoing.CharacterCreatePacket{Character: other})
+					sendCharacterCreate(conn, other)
 				}
 			}
 		}
@@ -282,3 +282,11 @@ func (s *AreaServiceImpl) SendAreaObjectsOnly(char *model.Character) {
 		}
 	})
 }
+
+// sendCharacterCreate shows char to conn, keeping invisible GMs and hidden players out of sight.
+func sendCharacterCreate(conn protocol.Connection, char *model.Character) {
+	conn.Send(&outgoing.CharacterCreatePacket{Character: char})
+	if char.AdminInvisible || char.Hidden {
+		conn.Send(&outgoing.SetInvisiblePacket{CharIndex: char.CharIndex, Invisible: true})
+	}
+}
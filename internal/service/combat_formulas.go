@@ -38,6 +38,16 @@ func (f *CombatFormulas) GetEvasionPower(char *model.Character) int {
 	return int(lTemp + (2.5 * float32(utils.Max(int(char.Level)-12, 0))))
 }
 
+// AttackSkill returns the skill that governs attacks with weapon (nil when fighting bare-handed).
+func (f *CombatFormulas) AttackSkill(weapon *model.Object) model.Skill {
+	if weapon == nil {
+		return model.Wrestling
+	} else if weapon.Ranged {
+		return model.Projectiles
+	}
+	return model.MeleeCombat
+}
+
 func (f *CombatFormulas) GetAttackPower(char *model.Character, weapon *model.Object) int {
 	mod := f.archetypeModifiers[char.Archetype]
 	if mod == nil {
@@ -78,7 +88,7 @@ func (f *CombatFormulas) CalculateHitChance(attackerPower, victimEvasion int) in
 	return utils.Max(10, utils.Min(90, chance))
 }
 
-func (f *CombatFormulas) CalculateDamage(attacker *model.Character, weapon *model.Object, isNpc bool) int {
+func (f *CombatFormulas) CalculateDamage(attacker *model.Character, weapon *model.Object, ammo *model.Object, isNpc bool) int {
 	mod := f.archetypeModifiers[attacker.Archetype]
 	if mod == nil {
 		return 0
@@ -91,6 +101,10 @@ func (f *CombatFormulas) CalculateDamage(attacker *model.Character, weapon *mode
 	if weapon != nil {
 		weaponDmg = utils.RandomNumber(weapon.MinHit, weapon.MaxHit)
 		maxWeaponDmg = weapon.MaxHit
+		if ammo != nil {
+			weaponDmg += utils.RandomNumber(ammo.MinHit, ammo.MaxHit)
+			maxWeaponDmg += ammo.MaxHit
+		}
 		if weapon.Ranged {
 			modClase = mod.ProjectileDamage
 		} else {
@@ -119,15 +133,43 @@ func (f *CombatFormulas) CalculateDamage(attacker *model.Character, weapon *mode
 	return int(totalDmg)
 }
 
-func (f *CombatFormulas) CheckCrit(char *model.Character) bool {
-	// Standard AO crit chance
-	return rand.Intn(100) < 10
+// CheckCrit rolls a bandit's critical hit. Only melee and bare-handed blows can be critical.
+func (f *CombatFormulas) CheckCrit(char *model.Character, weapon *model.Object, chance float64) bool {
+	if char.Archetype != model.Bandit || (weapon != nil && weapon.Ranged) {
+		return false
+	}
+	return rand.Float64() < chance
+}
+
+// CheckStab rolls an assassin's backstab, which needs a stabbing weapon and a hit from behind.
+// The odds grow with the Stab skill, reaching chance at MaxSkillPoints.
+func (f *CombatFormulas) CheckStab(char *model.Character, weapon *model.Object, fromBehind bool, chance float64) bool {
+	if char.Archetype != model.Assasin || weapon == nil || weapon.Ranged || !weapon.Stabbing || !fromBehind {
+		return false
+	}
+	return rand.Float64() < chance*float64(char.Skills[model.Stab])/model.MaxSkillPoints
 }
 
-func (f *CombatFormulas) CheckStab(char *model.Character) bool {
-	if char.Archetype != model.Assasin {
+// CheckStun rolls whether a bare-handed hit stuns the victim. The odds grow with the Wrestling skill.
+func (f *CombatFormulas) CheckStun(char *model.Character, weapon *model.Object, chance float64) bool {
+	if weapon != nil {
 		return false
 	}
-	// Simplified stab chance
-	return rand.Intn(100) < (char.Skills[model.Stab] / 5)
+	return rand.Float64() < chance*float64(char.Skills[model.Wrestling])/model.MaxSkillPoints
+}
+
+// IsBehind tells whether pos is the tile right behind a victim standing at victimPos and facing heading.
+func (f *CombatFormulas) IsBehind(pos, victimPos model.Position, heading model.Heading) bool {
+	behind := victimPos
+	switch heading {
+	case model.North:
+		behind.Y++
+	case model.South:
+		behind.Y--
+	case model.East:
+		behind.X--
+	case model.West:
+		behind.X++
+	}
+	return pos == behind
 }
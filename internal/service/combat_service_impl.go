@@ -3,6 +3,7 @@ package service
 import (
 	"fmt"
 	"math/rand"
+	"time"
 
 	"github.com/ao-go-server/internal/config"
 	"github.com/ao-go-server/internal/model"
@@ -10,6 +11,35 @@ import (
 	"github.com/ao-go-server/internal/utils"
 )
 
+// attackOutcome tells apart attacks that were not allowed from misses and hits.
+type attackOutcome int
+
+const (
+	attackRefused attackOutcome = iota
+	attackMissed
+	attackHit
+)
+
+// combatSpecial is the class special that landed along with a hit, if any.
+type combatSpecial int
+
+const (
+	specialNone combatSpecial = iota
+	specialBackstab
+	specialCritical
+	specialStun
+)
+
+// Sounds and effects the client plays for each class special
+const (
+	sndBackstab = 12
+	sndCritical = 14
+	sndStun     = 15
+	fxBackstab  = 14
+	fxCritical  = 13
+	fxStun      = 11
+)
+
 type CombatServiceImpl struct {
 	messageService  MessageService
 	objectService   ObjectService
@@ -18,10 +48,15 @@ type CombatServiceImpl struct {
 	formulas        *CombatFormulas
 	intervals       IntervalService
 	trainingService TrainingService
+	questService    QuestService
+	duelService     DuelService
+	lootService     LootService
+	effectService   EffectService
 	config          *config.Config
+	globalBalance   *model.GlobalBalanceConfig
 }
 
-func NewCombatServiceImpl(messageService MessageService, objectService ObjectService, npcService NpcService, mapService MapService, formulas *CombatFormulas, intervals IntervalService, trainingService TrainingService, cfg *config.Config) CombatService {
+func NewCombatServiceImpl(messageService MessageService, objectService ObjectService, npcService NpcService, mapService MapService, formulas *CombatFormulas, intervals IntervalService, trainingService TrainingService, questService QuestService, duelService DuelService, lootService LootService, effectService EffectService, cfg *config.Config, globalBalance *model.GlobalBalanceConfig) CombatService {
 	return &CombatServiceImpl{
 		messageService:  messageService,
 		objectService:   objectService,
@@ -30,7 +65,12 @@ func NewCombatServiceImpl(messageService MessageService, objectService ObjectSer
 		formulas:        formulas,
 		intervals:       intervals,
 		trainingService: trainingService,
+		questService:    questService,
+		duelService:     duelService,
+		lootService:     lootService,
+		effectService:   effectService,
 		config:          cfg,
+		globalBalance:   globalBalance,
 	}
 }
 
@@ -40,6 +80,10 @@ func (s *CombatServiceImpl) ResolveAttack(attacker *model.Character, target any)
 		return
 	}
 
+	if attacker.HasEffect(model.EffectStun) {
+		return
+	}
+
 	// Check stamina
 	if attacker.Stamina < 10 {
 		s.messageService.SendConsoleMessage(attacker, "Estás muy cansado para luchar.", outgoing.INFO)
@@ -51,39 +95,184 @@ func (s *CombatServiceImpl) ResolveAttack(attacker *model.Character, target any)
 		return
 	}
 
+	if weapon := s.objectService.GetEquipped(attacker, model.OTWeapon); weapon != nil && weapon.Ranged {
+		s.messageService.SendConsoleMessage(attacker, "No puedes usar así esta arma.", outgoing.INFO)
+		return
+	}
+
+	// Attacking gives a disguise away
+	s.effectService.Dispel(attacker, model.EffectMimicry)
+
 	switch t := target.(type) {
 	case *model.Character:
-		s.resolvePVP(attacker, t)
+		s.resolvePVP(attacker, t, nil)
 	case *model.WorldNPC:
-		s.resolvePVE(attacker, t)
+		s.resolvePVE(attacker, t, nil)
+	}
+
+	s.finishAttack(attacker)
+}
+
+// ResolveRangedAttack shoots the attacker's ranged weapon at a target in sight, spending one unit
+// of the equipped ammunition. Missed arrows may be left on the ground near the target.
+func (s *CombatServiceImpl) ResolveRangedAttack(attacker *model.Character, target any) {
+	if attacker.Dead {
+		s.messageService.SendConsoleMessage(attacker, "¡Estás muerto!", outgoing.INFO)
+		return
+	}
+
+	if attacker.HasEffect(model.EffectStun) {
+		return
+	}
+
+	weapon := s.objectService.GetEquipped(attacker, model.OTWeapon)
+	if weapon == nil || !weapon.Ranged {
+		s.messageService.SendConsoleMessage(attacker, "No tienes equipada un arma a distancia.", outgoing.INFO)
+		return
+	}
+
+	var targetPos model.Position
+	switch t := target.(type) {
+	case *model.Character:
+		if t == attacker {
+			s.messageService.SendConsoleMessage(attacker, "No puedes atacarte a ti mismo.", outgoing.INFO)
+			return
+		}
+		targetPos = t.Position
+	case *model.WorldNPC:
+		targetPos = t.Position
+	default:
+		return
+	}
+
+	if attacker.Stamina < 10 {
+		s.messageService.SendConsoleMessage(attacker, "Estás muy cansado para luchar.", outgoing.INFO)
+		return
+	}
+
+	if !s.intervals.CanAttack(attacker) {
+		return
+	}
+
+	gameMap := s.mapService.GetMap(attacker.Position.Map)
+	if gameMap == nil || !s.messageService.AreaService().InRange(attacker.Position, targetPos) {
+		s.messageService.SendConsoleMessage(attacker, "Estás demasiado lejos.", outgoing.INFO)
+		return
+	}
+
+	inSight := false
+	gameMap.View(func(m *model.Map) {
+		inSight = m.HasLineOfSight(attacker.Position, targetPos)
+	})
+	if !inSight {
+		s.messageService.SendConsoleMessage(attacker, "No tienes línea de visión con el objetivo.", outgoing.INFO)
+		return
+	}
+
+	var ammo *model.Object
+	ammoSlot := -1
+	if weapon.NeedsAmmo {
+		ammoSlot, ammo = s.getEquippedAmmo(attacker)
+		if ammo == nil {
+			s.messageService.SendConsoleMessage(attacker, "No tienes municiones.", outgoing.INFO)
+			return
+		}
+	}
+
+	s.effectService.Dispel(attacker, model.EffectMimicry)
+
+	var outcome attackOutcome
+	switch t := target.(type) {
+	case *model.Character:
+		outcome = s.resolvePVP(attacker, t, ammo)
+	case *model.WorldNPC:
+		outcome = s.resolvePVE(attacker, t, ammo)
+	}
+	if outcome == attackRefused {
+		return
 	}
 
-	// Update last attack time
+	if ammo != nil {
+		s.spendAmmo(attacker, ammoSlot)
+		if outcome == attackMissed && rand.Float64() < s.globalBalance.ArrowDropChance {
+			s.dropArrow(ammo, targetPos)
+		}
+	}
+
+	s.finishAttack(attacker)
+}
+
+// finishAttack starts the attack interval and charges the attacker's stamina.
+func (s *CombatServiceImpl) finishAttack(attacker *model.Character) {
 	s.intervals.UpdateLastAttack(attacker)
 
-	// Consume stamina
 	attacker.Stamina -= utils.RandomNumber(1, 10)
 	if attacker.Stamina < 0 {
 		attacker.Stamina = 0
 	}
-	s.messageService.UserService().GetConnection(attacker).Send(outgoing.NewUpdateUserStatsPacket(attacker))
+	if conn := s.messageService.UserService().GetConnection(attacker); conn != nil {
+		conn.Send(outgoing.NewUpdateUserStatsPacket(attacker))
+	}
 }
 
-func (s *CombatServiceImpl) resolvePVP(attacker *model.Character, victim *model.Character) {
-	if s.mapService.IsSafeZone(attacker.Position) || s.mapService.IsSafeZone(victim.Position) ||
-		!s.mapService.IsPkMap(attacker.Position.Map) || !s.mapService.IsPkMap(victim.Position.Map) {
-		s.messageService.SendConsoleMessage(attacker, "No puedes combatir en zona segura.", outgoing.INFO)
+func (s *CombatServiceImpl) spendAmmo(char *model.Character, slotIdx int) {
+	slot := char.Inventory.GetSlot(slotIdx)
+	slot.Amount--
+	if slot.Amount <= 0 {
+		slot.ObjectID = 0
+		slot.Amount = 0
+		slot.Equipped = false
+		s.messageService.SendConsoleMessage(char, "Te has quedado sin municiones.", outgoing.INFO)
+	}
+
+	if conn := s.messageService.UserService().GetConnection(char); conn != nil {
+		conn.Send(&outgoing.ChangeInventorySlotPacket{
+			Slot:     byte(slotIdx + 1),
+			Object:   s.objectService.GetObject(slot.ObjectID),
+			Amount:   slot.Amount,
+			Equipped: slot.Equipped,
+		})
+	}
+}
+
+// dropArrow leaves a missed arrow on the ground, stacking it with arrows of the same kind already there.
+func (s *CombatServiceImpl) dropArrow(ammo *model.Object, pos model.Position) {
+	if existing := s.mapService.GetObjectAt(pos); existing != nil && existing.Object.ID == ammo.ID {
+		existing.Amount++
+		return
+	}
+
+	dropPos := s.messageService.FindDropPosition(pos)
+	if dropPos == nil {
 		return
 	}
 
-	weapon := s.getEquippedWeapon(attacker)
+	s.mapService.PutObject(*dropPos, &model.WorldObject{Object: ammo, Amount: 1})
+	s.messageService.SendToArea(&outgoing.ObjectCreatePacket{
+		X:            dropPos.X,
+		Y:            dropPos.Y,
+		GraphicIndex: int16(ammo.GraphicIndex),
+	}, *dropPos)
+}
+
+func (s *CombatServiceImpl) resolvePVP(attacker *model.Character, victim *model.Character, ammo *model.Object) attackOutcome {
+	// Arena fights are allowed anywhere, duels only outside safe zones on PK maps (see IsConsensualFight)
+	consensual := s.duelService.IsConsensualFight(attacker, victim)
+	if !consensual && (s.mapService.IsSafeZone(attacker.Position) || s.mapService.IsSafeZone(victim.Position) ||
+		!s.mapService.IsPkMap(attacker.Position.Map) || !s.mapService.IsPkMap(victim.Position.Map)) {
+		s.messageService.SendConsoleMessage(attacker, "No puedes combatir en zona segura.", outgoing.INFO)
+		return attackRefused
+	}
+
+	weapon := s.objectService.GetEquipped(attacker, model.OTWeapon)
 
 	// Hit check
 	attackerPower := s.formulas.GetAttackPower(attacker, weapon)
 	victimEvasion := s.formulas.GetEvasionPower(victim)
 
 	// Shield bonus
-	if s.getEquippedShield(victim) != nil {
+	shielded := s.objectService.GetEquipped(victim, model.OTShield) != nil
+	if shielded {
 		victimEvasion += s.formulas.GetShieldEvasionPower(victim)
 	}
 
@@ -92,6 +281,7 @@ func (s *CombatServiceImpl) resolvePVP(attacker *model.Character, victim *model.
 	if rand.Intn(100) >= chance {
 		s.messageService.SendConsoleMessage(attacker, "¡Has fallado el golpe!", outgoing.FIGHT)
 		s.messageService.SendConsoleMessage(victim, fmt.Sprintf("¡%s ha fallado el golpe!", attacker.Name), outgoing.FIGHT)
+		s.trainDodge(victim, shielded)
 
 		// Play miss sound
 		s.messageService.SendToArea(&outgoing.PlayWavePacket{
@@ -100,14 +290,14 @@ func (s *CombatServiceImpl) resolvePVP(attacker *model.Character, victim *model.
 			Y:    victim.Position.Y,
 		}, victim.Position)
 
-		return
+		return attackMissed
 	}
 
 	// Damage calculation
-	damage := s.formulas.CalculateDamage(attacker, weapon, false)
+	damage := s.formulas.CalculateDamage(attacker, weapon, ammo, false)
 
 	// Armor defense
-	armor := s.getEquippedArmor(victim)
+	armor := s.objectService.GetEquipped(victim, model.OTArmor)
 	if armor != nil {
 		defense := utils.RandomNumber(armor.MinDef, armor.MaxDef)
 		damage -= defense
@@ -117,40 +307,31 @@ func (s *CombatServiceImpl) resolvePVP(attacker *model.Character, victim *model.
 		damage = 1
 	}
 
+	special, damage := s.rollSpecial(attacker, weapon, ammo, victim.Position, victim.Heading, damage, false)
+	if special == specialStun {
+		s.effectService.Apply(victim, model.EffectStun, 0, time.Duration(s.globalBalance.StunDuration)*time.Millisecond)
+	}
+
 	victim.Hp -= damage
 	if victim.Hp < 0 {
 		victim.Hp = 0
 	}
 
 	// Remove paralysis on hit
-	if victim.Paralyzed || victim.Immobilized {
-		victim.Paralyzed = false
-		victim.Immobilized = false
+	paralyzed := s.effectService.Dispel(victim, model.EffectParalysis)
+	immobilized := s.effectService.Dispel(victim, model.EffectImmobilization)
+	if paralyzed || immobilized {
 		s.messageService.SendConsoleMessage(victim, "¡Has recuperado el movimiento!", outgoing.INFO)
-		if conn := s.messageService.UserService().GetConnection(victim); conn != nil {
-			conn.Send(&outgoing.ParalyzeOkPacket{})
-		}
 	}
 
 	// Feedback
-	s.messageService.SendConsoleMessage(attacker, fmt.Sprintf("¡Has golpeado a %s por %d!", victim.Name, damage), outgoing.FIGHT)
-	s.messageService.SendConsoleMessage(victim, fmt.Sprintf("¡%s te ha golpeado por %d!", attacker.Name, damage), outgoing.FIGHT)
-
-	// Play hit sound
-	s.messageService.SendToArea(&outgoing.PlayWavePacket{
-		Wave: 10, // SND_HIT (Sword/Melee)
-		X:    victim.Position.X,
-		Y:    victim.Position.Y,
-	}, victim.Position)
-
-	// Blood FX
-	s.messageService.SendToArea(&outgoing.CreateFxPacket{
-		CharIndex: victim.CharIndex,
-		FxID:      1, // Blood placeholder
-		Loops:     0,
-	}, victim.Position)
+	s.announceHit(attacker, victim, victim.Name, victim.CharIndex, victim.Position, damage, special)
+	s.trainHit(attacker, weapon, special)
 
 	if victim.Hp <= 0 {
+		if consensual && s.duelService.HandleDefeat(attacker, victim) {
+			return attackHit
+		}
 		s.messageService.HandleDeath(victim, "")
 	} else {
 		connVictim := s.messageService.UserService().GetConnection(victim)
@@ -158,20 +339,22 @@ func (s *CombatServiceImpl) resolvePVP(attacker *model.Character, victim *model.
 			connVictim.Send(outgoing.NewUpdateUserStatsPacket(victim))
 		}
 	}
+
+	return attackHit
 }
 
-func (s *CombatServiceImpl) resolvePVE(attacker *model.Character, victim *model.WorldNPC) {
+func (s *CombatServiceImpl) resolvePVE(attacker *model.Character, victim *model.WorldNPC, ammo *model.Object) attackOutcome {
 	if !victim.NPC.Hostile {
 		s.messageService.SendConsoleMessage(attacker, "No puedes atacar a una criatura pacífica.", outgoing.INFO)
-		return
+		return attackRefused
 	}
 
 	if s.mapService.IsSafeZone(attacker.Position) || s.mapService.IsSafeZone(victim.Position) {
 		s.messageService.SendConsoleMessage(attacker, "No puedes combatir en zona segura.", outgoing.INFO)
-		return
+		return attackRefused
 	}
 
-	weapon := s.getEquippedWeapon(attacker)
+	weapon := s.objectService.GetEquipped(attacker, model.OTWeapon)
 
 	// Hit check
 	attackerPower := s.formulas.GetAttackPower(attacker, weapon)
@@ -189,11 +372,11 @@ func (s *CombatServiceImpl) resolvePVE(attacker *model.Character, victim *model.
 			Y:    victim.Position.Y,
 		}, victim.Position)
 
-		return
+		return attackMissed
 	}
 
 	// Damage
-	damage := s.formulas.CalculateDamage(attacker, weapon, true)
+	damage := s.formulas.CalculateDamage(attacker, weapon, ammo, true)
 
 	// NPC Defense
 	damage -= victim.NPC.Defense
@@ -202,22 +385,126 @@ func (s *CombatServiceImpl) resolvePVE(attacker *model.Character, victim *model.
 		damage = 1
 	}
 
+	special, damage := s.rollSpecial(attacker, weapon, ammo, victim.Position, victim.Heading, damage, true)
+	if special == specialStun {
+		victim.StunnedUntil = time.Now().Add(time.Duration(s.globalBalance.StunDuration) * time.Millisecond)
+	}
+
 	victim.HP -= damage
-	s.messageService.SendConsoleMessage(attacker, fmt.Sprintf("¡Has golpeado a la criatura por %d!", damage), outgoing.FIGHT)
+	s.announceHit(attacker, nil, "la criatura", victim.Index, victim.Position, damage, special)
+	s.trainHit(attacker, weapon, special)
+
+	// Pets join their owner's fight
+	for _, pet := range s.npcService.GetPets(int(attacker.CharIndex)) {
+		if pet != victim {
+			pet.TargetNpc = victim.Index
+		}
+	}
 
 	// Grant experience proportional to damage
 	s.grantExperience(attacker, victim, damage)
 
-	// Play hit sound
-	s.messageService.SendToArea(&outgoing.PlayWavePacket{
-		Wave: 10, // SND_HIT (Sword/Melee)
-		X:    victim.Position.X,
-		Y:    victim.Position.Y,
-	}, victim.Position)
-
 	if victim.HP <= 0 {
 		s.handleNpcDeath(attacker, victim)
 	}
+
+	return attackHit
+}
+
+// rollSpecial picks the class special, if any, that goes along with a hit and returns the damage
+// it ends up dealing. Backstabs need to be dealt in melee from the tile behind the victim.
+func (s *CombatServiceImpl) rollSpecial(attacker *model.Character, weapon, ammo *model.Object, victimPos model.Position, victimHeading model.Heading, damage int, againstNpc bool) (combatSpecial, int) {
+	fromBehind := ammo == nil && s.formulas.IsBehind(attacker.Position, victimPos, victimHeading)
+	if s.formulas.CheckStab(attacker, weapon, fromBehind, s.globalBalance.BackstabChance) {
+		multiplier := s.globalBalance.BackstabDamage
+		if againstNpc {
+			multiplier *= 2
+		}
+		return specialBackstab, int(float64(damage) * multiplier)
+	}
+
+	if s.formulas.CheckCrit(attacker, weapon, s.globalBalance.CriticalChance) {
+		return specialCritical, int(float64(damage) * s.globalBalance.CriticalDamage)
+	}
+
+	if s.formulas.CheckStun(attacker, weapon, s.globalBalance.StunChance) {
+		return specialStun, damage
+	}
+
+	return specialNone, damage
+}
+
+// announceHit tells the attacker and the victim (nil for creatures) about a landed hit and plays
+// its sound and effect, which differ for each class special.
+func (s *CombatServiceImpl) announceHit(attacker, victim *model.Character, victimName string, charIndex int16, pos model.Position, damage int, special combatSpecial) {
+	wave, fx := 10, 1 // SND_HIT (Sword/Melee), blood placeholder
+
+	switch special {
+	case specialBackstab:
+		wave, fx = sndBackstab, fxBackstab
+		s.messageService.SendConsoleMessage(attacker, fmt.Sprintf("¡Has apuñalado a %s por %d!", victimName, damage), outgoing.FIGHT)
+		if victim != nil {
+			s.messageService.SendConsoleMessage(victim, fmt.Sprintf("¡%s te ha apuñalado por %d!", attacker.Name, damage), outgoing.FIGHT)
+		}
+	case specialCritical:
+		wave, fx = sndCritical, fxCritical
+		s.messageService.SendConsoleMessage(attacker, fmt.Sprintf("¡Has golpeado críticamente a %s por %d!", victimName, damage), outgoing.FIGHT)
+		if victim != nil {
+			s.messageService.SendConsoleMessage(victim, fmt.Sprintf("¡%s te ha golpeado críticamente por %d!", attacker.Name, damage), outgoing.FIGHT)
+		}
+	default:
+		s.messageService.SendConsoleMessage(attacker, fmt.Sprintf("¡Has golpeado a %s por %d!", victimName, damage), outgoing.FIGHT)
+		if victim != nil {
+			s.messageService.SendConsoleMessage(victim, fmt.Sprintf("¡%s te ha golpeado por %d!", attacker.Name, damage), outgoing.FIGHT)
+		}
+	}
+
+	if special == specialStun {
+		wave, fx = sndStun, fxStun
+		s.messageService.SendConsoleMessage(attacker, fmt.Sprintf("¡Has aturdido a %s!", victimName), outgoing.FIGHT)
+		if victim != nil {
+			s.messageService.SendConsoleMessage(victim, fmt.Sprintf("¡%s te ha aturdido!", attacker.Name), outgoing.FIGHT)
+		}
+	}
+
+	s.messageService.SendToArea(&outgoing.PlayWavePacket{
+		Wave: byte(wave),
+		X:    pos.X,
+		Y:    pos.Y,
+	}, pos)
+
+	// Plain hits on creatures don't bleed
+	if victim == nil && special == specialNone {
+		return
+	}
+	s.messageService.SendToArea(&outgoing.CreateFxPacket{
+		CharIndex: charIndex,
+		FxID:      int16(fx),
+		Loops:     0,
+	}, pos)
+}
+
+// trainHit trains the skills used by a landed hit.
+func (s *CombatServiceImpl) trainHit(attacker *model.Character, weapon *model.Object, special combatSpecial) {
+	if special == specialBackstab {
+		s.trainingService.ImproveSkill(attacker, model.Stab)
+	}
+	s.trainingService.ImproveSkill(attacker, s.formulas.AttackSkill(weapon))
+}
+
+// trainDodge trains the skill that stopped a missed blow. With a shield equipped the blow is
+// rejected by it as often as the victim's shield defense outweighs their evasion.
+func (s *CombatServiceImpl) trainDodge(victim *model.Character, shielded bool) {
+	if shielded {
+		defense, evasion := victim.Skills[model.Defense], victim.Skills[model.Evasion]
+		block := max(1, min(99, 100*defense/max(defense+evasion, 1)))
+		if rand.Intn(100) < block {
+			s.messageService.SendConsoleMessage(victim, "¡Has rechazado el ataque con el escudo!", outgoing.FIGHT)
+			s.trainingService.ImproveSkill(victim, model.Defense)
+			return
+		}
+	}
+	s.trainingService.ImproveSkill(victim, model.Evasion)
 }
 
 func (s *CombatServiceImpl) NpcAtacaUser(npc *model.WorldNPC, victim *model.Character) bool {
@@ -238,37 +525,54 @@ func (s *CombatServiceImpl) NpcAtacaUser(npc *model.WorldNPC, victim *model.Char
 		return false
 	}
 
-	// Hit check
-	attackerPower := npc.NPC.AttackPower
-	victimEvasion := s.formulas.GetEvasionPower(victim)
-
-	// Shield bonus
-	if s.getEquippedShield(victim) != nil {
-		victimEvasion += s.formulas.GetShieldEvasionPower(victim)
+	// NPCs with AtacaDoble strike twice per attack interval
+	strikes := 1
+	if npc.NPC.DoubleAttack {
+		strikes = 2
+	}
+	for i := 0; i < strikes && !victim.Dead; i++ {
+		s.npcGolpeaUser(npc, victim)
 	}
 
-	chance := s.formulas.CalculateHitChance(attackerPower, victimEvasion)
+	// Update interval, even on miss
+	s.intervals.UpdateNPCLastAttack(npc)
 
-	if rand.Intn(100) >= chance {
-		s.messageService.SendConsoleMessage(victim, fmt.Sprintf("¡%s ha fallado el golpe!", npc.NPC.Name), outgoing.FIGHT)
+	return true
+}
 
-		// Play miss sound
-		s.messageService.SendToArea(&outgoing.PlayWavePacket{
-			Wave: 2, // SND_MISS
-			X:    victim.Position.X,
-			Y:    victim.Position.Y,
-		}, victim.Position)
+func (s *CombatServiceImpl) npcGolpeaUser(npc *model.WorldNPC, victim *model.Character) {
+	// Hit check, skipped by NPCs with GolpeExacto
+	if !npc.NPC.ExactHit {
+		attackerPower := npc.NPC.AttackPower
+		victimEvasion := s.formulas.GetEvasionPower(victim)
 
-		// Update interval even on miss
-		s.intervals.UpdateNPCLastAttack(npc)
-		return true
+		// Shield bonus
+		shielded := s.objectService.GetEquipped(victim, model.OTShield) != nil
+		if shielded {
+			victimEvasion += s.formulas.GetShieldEvasionPower(victim)
+		}
+
+		chance := s.formulas.CalculateHitChance(attackerPower, victimEvasion)
+
+		if rand.Intn(100) >= chance {
+			s.messageService.SendConsoleMessage(victim, fmt.Sprintf("¡%s ha fallado el golpe!", npc.NPC.Name), outgoing.FIGHT)
+			s.trainDodge(victim, shielded)
+
+			// Play miss sound
+			s.messageService.SendToArea(&outgoing.PlayWavePacket{
+				Wave: 2, // SND_MISS
+				X:    victim.Position.X,
+				Y:    victim.Position.Y,
+			}, victim.Position)
+			return
+		}
 	}
 
 	// Damage calculation
 	damage := utils.RandomNumber(npc.NPC.MinHit, npc.NPC.MaxHit)
 
 	// Armor defense
-	armor := s.getEquippedArmor(victim)
+	armor := s.objectService.GetEquipped(victim, model.OTArmor)
 	if armor != nil {
 		defense := utils.RandomNumber(armor.MinDef, armor.MaxDef)
 		damage -= defense
@@ -302,19 +606,94 @@ func (s *CombatServiceImpl) NpcAtacaUser(npc *model.WorldNPC, victim *model.Char
 
 	if victim.Hp <= 0 {
 		s.messageService.HandleDeath(victim, "")
-	} else {
-		connVictim := s.messageService.UserService().GetConnection(victim)
-		if connVictim != nil {
-			connVictim.Send(outgoing.NewUpdateUserStatsPacket(victim))
-		}
+		return
 	}
 
-	// Update interval
-	s.intervals.UpdateNPCLastAttack(npc)
+	if npc.NPC.Poisons && !victim.HasEffect(model.EffectPoison) && rand.Intn(100) < model.NpcPoisonChance {
+		s.effectService.Apply(victim, model.EffectPoison, model.PoisonDamage, 0)
+		s.messageService.SendConsoleMessage(victim, "¡¡La criatura te ha envenenado!!", outgoing.FIGHT)
+	}
+
+	connVictim := s.messageService.UserService().GetConnection(victim)
+	if connVictim != nil {
+		connVictim.Send(outgoing.NewUpdateUserStatsPacket(victim))
+	}
+}
+
+func (s *CombatServiceImpl) NpcAtacaNpc(attacker, victim *model.WorldNPC) bool {
+	if attacker.Paralyzed || victim.HP <= 0 {
+		return false
+	}
+
+	// Pets follow the same safe zone rules as their owners
+	if attacker.OwnerIndex != 0 && (s.mapService.IsSafeZone(attacker.Position) || s.mapService.IsSafeZone(victim.Position)) {
+		return false
+	}
+
+	if !s.intervals.CanNPCAttack(attacker) {
+		return false
+	}
+	s.intervals.UpdateNPCLastAttack(attacker)
+
+	// The victim fights back
+	if victim.TargetNpc == 0 {
+		victim.TargetNpc = attacker.Index
+	}
+
+	owner := s.npcOwner(attacker)
+	victimOwner := s.npcOwner(victim)
+
+	chance := s.formulas.CalculateHitChance(attacker.NPC.AttackPower, victim.NPC.EvasionPower)
+	if !attacker.NPC.ExactHit && rand.Intn(100) >= chance {
+		s.messageService.SendToArea(&outgoing.PlayWavePacket{
+			Wave: 2, // SND_MISS
+			X:    victim.Position.X,
+			Y:    victim.Position.Y,
+		}, victim.Position)
+		return true
+	}
+
+	damage := utils.RandomNumber(attacker.NPC.MinHit, attacker.NPC.MaxHit) - victim.NPC.Defense
+	if damage < 1 {
+		damage = 1
+	}
+	victim.HP -= damage
+
+	s.messageService.SendToArea(&outgoing.PlayWavePacket{
+		Wave: 10, // SND_HIT
+		X:    victim.Position.X,
+		Y:    victim.Position.Y,
+	}, victim.Position)
+
+	if owner != nil {
+		s.messageService.SendConsoleMessage(owner, fmt.Sprintf("¡%s ha golpeado a %s por %d!", attacker.NPC.Name, victim.NPC.Name, damage), outgoing.FIGHT)
+		s.grantExperience(owner, victim, damage)
+	}
+	if victimOwner != nil {
+		s.messageService.SendConsoleMessage(victimOwner, fmt.Sprintf("¡%s ha golpeado a tu %s por %d!", attacker.NPC.Name, victim.NPC.Name, damage), outgoing.FIGHT)
+	}
+
+	if victim.HP <= 0 {
+		attacker.TargetNpc = 0
+		if owner != nil {
+			s.handleNpcDeath(owner, victim)
+		} else {
+			s.messageService.SendToArea(&outgoing.CharacterRemovePacket{CharIndex: victim.Index}, victim.Position)
+			s.npcService.RemoveNPC(victim, s.mapService)
+		}
+	}
 
 	return true
 }
 
+// npcOwner returns the player a summoned or tamed NPC belongs to, if any.
+func (s *CombatServiceImpl) npcOwner(npc *model.WorldNPC) *model.Character {
+	if npc.OwnerIndex == 0 {
+		return nil
+	}
+	return s.messageService.UserService().GetCharacterByIndex(int16(npc.OwnerIndex))
+}
+
 func (s *CombatServiceImpl) grantExperience(attacker *model.Character, victim *model.WorldNPC, damage int) {
 	if victim.NPC.MaxHp == 0 || victim.NPC.Exp == 0 || victim.RemainingExp <= 0 {
 		return
@@ -351,74 +730,23 @@ func (s *CombatServiceImpl) handleNpcDeath(killer *model.Character, npc *model.W
 		s.trainingService.CheckLevel(killer)
 	}
 
-	// Drop logic
-	for _, drop := range npc.NPC.Drops {
-		obj := s.objectService.GetObject(drop.ObjectID)
-		if obj != nil {
-			amount := drop.Amount
-			if obj.Type == model.OTMoney {
-				amount = int(float64(amount) * s.config.GoldMultiplier)
-			}
+	s.questService.OnNpcKilled(killer, npc)
 
-			// Check if drop position is valid
-			dropPos := npc.Position
-			validPos := s.mapService.IsInPlayableArea(int(dropPos.X), int(dropPos.Y)) && !s.mapService.IsBlocked(dropPos.Map, int(dropPos.X), int(dropPos.Y))
-
-			if validPos {
-				worldObj := &model.WorldObject{
-					Object: obj,
-					Amount: amount,
-				}
-				if s.mapService.GetObjectAt(dropPos) == nil {
-					s.mapService.PutObject(dropPos, worldObj)
-					s.messageService.SendToArea(&outgoing.ObjectCreatePacket{
-						X:            dropPos.X,
-						Y:            dropPos.Y,
-						GraphicIndex: int16(obj.GraphicIndex),
-					}, dropPos)
-				}
-			}
-		}
-	}
+	s.lootService.DropNpcLoot(killer, npc)
 
 	s.npcService.RemoveNPC(npc, s.mapService)
 }
 
-func (s *CombatServiceImpl) getEquippedWeapon(char *model.Character) *model.Object {
-	for i := 0; i < model.InventorySlots; i++ {
-		slot := char.Inventory.GetSlot(i)
-		if slot.Equipped {
-			obj := s.objectService.GetObject(slot.ObjectID)
-			if obj != nil && obj.Type == model.OTWeapon {
-				return obj
-			}
-		}
-	}
-	return nil
-}
-
-func (s *CombatServiceImpl) getEquippedShield(char *model.Character) *model.Object {
-	for i := 0; i < model.InventorySlots; i++ {
-		slot := char.Inventory.GetSlot(i)
-		if slot.Equipped {
-			obj := s.objectService.GetObject(slot.ObjectID)
-			if obj != nil && obj.Type == model.OTShield {
-				return obj
-			}
-		}
-	}
-	return nil
-}
-
-func (s *CombatServiceImpl) getEquippedArmor(char *model.Character) *model.Object {
+// getEquippedAmmo returns the inventory slot and object of the equipped ammunition, if any.
+func (s *CombatServiceImpl) getEquippedAmmo(char *model.Character) (int, *model.Object) {
 	for i := 0; i < model.InventorySlots; i++ {
 		slot := char.Inventory.GetSlot(i)
-		if slot.Equipped {
+		if slot.Equipped && slot.Amount > 0 {
 			obj := s.objectService.GetObject(slot.ObjectID)
-			if obj != nil && obj.Type == model.OTArmor {
-				return obj
+			if obj != nil && obj.Type == model.OTArrow {
+				return i, obj
 			}
 		}
 	}
-	return nil
+	return -1, nil
 }
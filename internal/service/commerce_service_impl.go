@@ -0,0 +1,318 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ao-go-server/internal/model"
+	"github.com/ao-go-server/internal/protocol/outgoing"
+)
+
+// MaxCommerceDistance is how far (in tiles) a player may stand from the merchant while trading.
+const MaxCommerceDistance = 5
+
+type CommerceServiceImpl struct {
+	npcService     NpcService
+	objectService  ObjectService
+	messageService MessageService
+	userService    UserService
+	globalBalance  *model.GlobalBalanceConfig
+	mu             sync.Mutex // Guards the merchants' stock
+}
+
+func NewCommerceServiceImpl(npcService NpcService, objectService ObjectService, messageService MessageService, userService UserService, globalBalance *model.GlobalBalanceConfig) CommerceService {
+	return &CommerceServiceImpl{
+		npcService:     npcService,
+		objectService:  objectService,
+		messageService: messageService,
+		userService:    userService,
+		globalBalance:  globalBalance,
+	}
+}
+
+func (s *CommerceServiceImpl) OpenCommerce(char *model.Character, npc *model.WorldNPC) {
+	conn := s.userService.GetConnection(char)
+	if conn == nil {
+		return
+	}
+
+	char.TradingNPCIndex = npc.Index
+	conn.Send(&outgoing.CommerceInitPacket{})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range npc.Inventory {
+		s.sendNpcSlot(char, npc, i+1)
+	}
+}
+
+func (s *CommerceServiceImpl) CloseCommerce(char *model.Character) {
+	char.TradingNPCIndex = 0
+	if conn := s.userService.GetConnection(char); conn != nil {
+		conn.Send(&outgoing.CommerceEndPacket{})
+	}
+}
+
+func (s *CommerceServiceImpl) BuyItem(char *model.Character, npcSlotIdx int, amount int) {
+	npc := s.tradingNpc(char)
+	if npc == nil || amount <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if npcSlotIdx < 1 || npcSlotIdx > len(npc.Inventory) {
+		return
+	}
+	npcSlot := &npc.Inventory[npcSlotIdx-1]
+	if npcSlot.ObjectID == 0 || npcSlot.Amount <= 0 {
+		s.messageService.SendConsoleMessage(char, "El comerciante no tiene más de ese objeto.", outgoing.INFO)
+		return
+	}
+
+	obj := s.objectService.GetObject(npcSlot.ObjectID)
+	if obj == nil {
+		return
+	}
+
+	if amount > npcSlot.Amount {
+		amount = npcSlot.Amount
+	}
+
+	totalPrice := obj.Value * amount
+	if char.Gold < totalPrice {
+		s.messageService.SendConsoleMessage(char, "No tienes suficiente oro.", outgoing.INFO)
+		return
+	}
+
+	if !char.Inventory.AddItem(obj.ID, amount) {
+		s.messageService.SendConsoleMessage(char, "No tienes espacio en el inventario.", outgoing.INFO)
+		return
+	}
+
+	char.Gold -= totalPrice
+	npcSlot.Amount -= amount
+
+	s.messageService.SendConsoleMessage(char, fmt.Sprintf("Has comprado %d %s por %d monedas de oro.", amount, obj.Name, totalPrice), outgoing.INFO)
+	s.syncGold(char)
+	for i := 0; i < model.InventorySlots; i++ {
+		if char.Inventory.Slots[i].ObjectID == obj.ID {
+			s.syncInventorySlot(char, i+1)
+		}
+	}
+	s.broadcastNpcSlot(npc, npcSlotIdx)
+}
+
+func (s *CommerceServiceImpl) SellItem(char *model.Character, slotIdx int, amount int) {
+	npc := s.tradingNpc(char)
+	if npc == nil || amount <= 0 {
+		return
+	}
+
+	itemSlot := char.Inventory.GetSlot(slotIdx - 1)
+	if itemSlot == nil || itemSlot.ObjectID == 0 || itemSlot.Amount <= 0 {
+		return
+	}
+
+	obj := s.objectService.GetObject(itemSlot.ObjectID)
+	if obj == nil {
+		return
+	}
+
+	if itemSlot.Equipped {
+		s.messageService.SendConsoleMessage(char, "No puedes vender un objeto equipado.", outgoing.INFO)
+		return
+	}
+
+	if !npc.NPC.BuysItemType(obj.Type) {
+		s.messageService.SendConsoleMessage(char, "Lo siento, no estoy interesado en este tipo de objetos.", outgoing.INFO)
+		return
+	}
+
+	if amount > itemSlot.Amount {
+		amount = itemSlot.Amount
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	npcSlotIdx := s.addToStock(npc, obj.ID, amount)
+	if npcSlotIdx == 0 {
+		s.messageService.SendConsoleMessage(char, "El comerciante no tiene lugar para más objetos.", outgoing.INFO)
+		return
+	}
+
+	sellPrice := int(float64(obj.Value*amount) * s.globalBalance.CommerceSellFraction)
+	if sellPrice < 1 {
+		sellPrice = 1
+	}
+
+	char.Gold += sellPrice
+	itemSlot.Amount -= amount
+	if itemSlot.Amount <= 0 {
+		itemSlot.ObjectID = 0
+		itemSlot.Amount = 0
+	}
+
+	s.messageService.SendConsoleMessage(char, fmt.Sprintf("Has vendido %d %s por %d monedas de oro.", amount, obj.Name, sellPrice), outgoing.INFO)
+	s.syncGold(char)
+	s.syncInventorySlot(char, slotIdx)
+	s.broadcastNpcSlot(npc, npcSlotIdx)
+}
+
+// RestockMerchants refills the stock of every merchant whose restock interval has elapsed
+// back to the amounts listed in npcs.dat. Objects players sold to it are kept.
+func (s *CommerceServiceImpl) RestockMerchants() {
+	interval := time.Duration(s.globalBalance.CommerceRestockInterval) * time.Second
+	if interval <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for _, npc := range s.npcService.GetWorldNpcs() {
+		if !npc.NPC.CanTrade || !npc.NPC.Restock || now.Sub(npc.LastRestock) < interval {
+			continue
+		}
+		npc.LastRestock = now
+
+		// Sold-out slots holding objects players sold can be reused
+		for i := range npc.Inventory {
+			if npc.Inventory[i].Amount <= 0 && !s.isStockItem(npc.NPC, npc.Inventory[i].ObjectID) {
+				npc.Inventory[i].ObjectID = 0
+				npc.Inventory[i].Amount = 0
+			}
+		}
+
+		for _, item := range npc.NPC.Inventory {
+			idx := s.findStockSlot(npc, item.ObjectID)
+			if idx == 0 {
+				continue
+			}
+			slot := &npc.Inventory[idx-1]
+			if slot.ObjectID == 0 {
+				slot.ObjectID = item.ObjectID
+			}
+			if slot.Amount < item.Amount {
+				slot.Amount = item.Amount
+			}
+		}
+
+		for i := range npc.Inventory {
+			s.broadcastNpcSlot(npc, i+1)
+		}
+	}
+}
+
+// tradingNpc returns the merchant the character is trading with, ending the trade if
+// the merchant is gone or the character walked away.
+func (s *CommerceServiceImpl) tradingNpc(char *model.Character) *model.WorldNPC {
+	if char.TradingNPCIndex == 0 {
+		return nil
+	}
+
+	npc := s.npcService.GetWorldNpcByIndex(char.TradingNPCIndex)
+	if npc == nil {
+		s.CloseCommerce(char)
+		return nil
+	}
+
+	if npc.Position.Map != char.Position.Map || char.Position.GetDistance(npc.Position) > MaxCommerceDistance {
+		s.messageService.SendConsoleMessage(char, "Estás demasiado lejos del vendedor.", outgoing.INFO)
+		return nil
+	}
+	return npc
+}
+
+// addToStock adds the objects to the merchant's stock and returns the 1-based slot used,
+// or 0 if the merchant has no room left.
+func (s *CommerceServiceImpl) addToStock(npc *model.WorldNPC, objectID int, amount int) int {
+	idx := s.findStockSlot(npc, objectID)
+	if idx == 0 {
+		if len(npc.Inventory) >= model.InventorySlots {
+			return 0
+		}
+		npc.Inventory = append(npc.Inventory, model.InventorySlot{})
+		idx = len(npc.Inventory)
+	}
+
+	slot := &npc.Inventory[idx-1]
+	slot.ObjectID = objectID
+	slot.Amount += amount
+	return idx
+}
+
+// findStockSlot returns the 1-based slot holding the object, else the first free slot, else 0.
+func (s *CommerceServiceImpl) findStockSlot(npc *model.WorldNPC, objectID int) int {
+	free := 0
+	for i, slot := range npc.Inventory {
+		if slot.ObjectID == objectID {
+			return i + 1
+		}
+		if slot.ObjectID == 0 && free == 0 {
+			free = i + 1
+		}
+	}
+	return free
+}
+
+func (s *CommerceServiceImpl) isStockItem(def *model.NPC, objectID int) bool {
+	for _, item := range def.Inventory {
+		if item.ObjectID == objectID {
+			return true
+		}
+	}
+	return false
+}
+
+// broadcastNpcSlot refreshes a merchant slot for everyone trading with it.
+func (s *CommerceServiceImpl) broadcastNpcSlot(npc *model.WorldNPC, slotIdx int) {
+	for _, char := range s.userService.GetLoggedCharacters() {
+		if char.TradingNPCIndex == npc.Index {
+			s.sendNpcSlot(char, npc, slotIdx)
+		}
+	}
+}
+
+func (s *CommerceServiceImpl) sendNpcSlot(char *model.Character, npc *model.WorldNPC, slotIdx int) {
+	conn := s.userService.GetConnection(char)
+	if conn == nil {
+		return
+	}
+
+	slot := npc.Inventory[slotIdx-1]
+	var obj *model.Object
+	if slot.Amount > 0 {
+		obj = s.objectService.GetObject(slot.ObjectID)
+	}
+	conn.Send(&outgoing.ChangeNpcInventorySlotPacket{
+		Slot:   byte(slotIdx),
+		Object: obj,
+		Amount: slot.Amount,
+	})
+}
+
+func (s *CommerceServiceImpl) syncInventorySlot(char *model.Character, slotIdx int) {
+	conn := s.userService.GetConnection(char)
+	if conn == nil {
+		return
+	}
+
+	slot := char.Inventory.Slots[slotIdx-1]
+	conn.Send(&outgoing.ChangeInventorySlotPacket{
+		Slot:     byte(slotIdx),
+		Object:   s.objectService.GetObject(slot.ObjectID),
+		Amount:   slot.Amount,
+		Equipped: slot.Equipped,
+	})
+}
+
+func (s *CommerceServiceImpl) syncGold(char *model.Character) {
+	if conn := s.userService.GetConnection(char); conn != nil {
+		conn.Send(&outgoing.UpdateGoldPacket{Gold: char.Gold})
+	}
+}
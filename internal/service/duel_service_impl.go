@@ -0,0 +1,414 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ao-go-server/internal/model"
+	"github.com/ao-go-server/internal/protocol/outgoing"
+)
+
+const DuelLogFile = "logs/duels.log"
+
+// DuelMaxDistance is how close both players must be to challenge or accept a duel.
+const DuelMaxDistance = 10
+
+type duelChallenge struct {
+	challenger string
+	expires    time.Time
+}
+
+type duel struct {
+	players   [2]string
+	mapID     int // Map the duel was agreed on, the duel only counts there
+	started   bool
+	countdown int       // Seconds left to start
+	nextCount time.Time // When the next second is announced
+	expires   time.Time // When a started duel is called a draw, zero for no limit
+}
+
+func (d *duel) opponent(name string) string {
+	if strings.EqualFold(d.players[0], name) {
+		return d.players[1]
+	}
+	return d.players[0]
+}
+
+type DuelServiceImpl struct {
+	userService    UserService
+	messageService MessageService
+	mapService     MapService
+	effectService  EffectService
+	globalBalance  *model.GlobalBalanceConfig
+	challenges     map[string]*duelChallenge // keyed by challenged player
+	duels          map[string]*duel          // keyed by both players
+	mu             sync.Mutex
+	logMu          sync.Mutex
+}
+
+func NewDuelServiceImpl(userService UserService, messageService MessageService, mapService MapService, effectService EffectService, globalBalance *model.GlobalBalanceConfig) DuelService {
+	return &DuelServiceImpl{
+		userService:    userService,
+		messageService: messageService,
+		mapService:     mapService,
+		effectService:  effectService,
+		globalBalance:  globalBalance,
+		challenges:     make(map[string]*duelChallenge),
+		duels:          make(map[string]*duel),
+	}
+}
+
+func duelKey(name string) string {
+	return strings.ToUpper(name)
+}
+
+func (s *DuelServiceImpl) Challenge(challenger *model.Character, targetName string) {
+	if challenger.Dead {
+		s.messageService.SendConsoleMessage(challenger, "¡Estás muerto!", outgoing.INFO)
+		return
+	}
+
+	var target *model.Character
+	for _, char := range s.userService.GetLoggedCharacters() {
+		if strings.EqualFold(char.Name, targetName) {
+			target = char
+			break
+		}
+	}
+	if target == nil {
+		s.messageService.SendConsoleMessage(challenger, "Usuario offline.", outgoing.INFO)
+		return
+	}
+
+	if target == challenger {
+		s.messageService.SendConsoleMessage(challenger, "No puedes desafiarte a ti mismo.", outgoing.INFO)
+		return
+	}
+
+	if !s.canDuel(challenger, target) {
+		return
+	}
+
+	timeout := time.Duration(s.globalBalance.DuelChallengeTimeout) * time.Second
+
+	s.mu.Lock()
+	s.challenges[duelKey(target.Name)] = &duelChallenge{
+		challenger: challenger.Name,
+		expires:    time.Now().Add(timeout),
+	}
+	s.mu.Unlock()
+
+	s.messageService.SendConsoleMessage(challenger, fmt.Sprintf("Has desafiado a %s a un duelo.", target.Name), outgoing.INFO)
+	s.messageService.SendConsoleMessage(target, fmt.Sprintf("%s te ha desafiado a un duelo. Escribe /ACEPTAR para aceptar o /RECHAZAR para rechazar.", challenger.Name), outgoing.INFOBOLD)
+}
+
+func (s *DuelServiceImpl) Accept(char *model.Character) {
+	s.mu.Lock()
+	challenge := s.challenges[duelKey(char.Name)]
+	delete(s.challenges, duelKey(char.Name))
+	s.mu.Unlock()
+
+	if challenge == nil || time.Now().After(challenge.expires) {
+		s.messageService.SendConsoleMessage(char, "No tienes desafíos pendientes.", outgoing.INFO)
+		return
+	}
+
+	challenger := s.userService.GetCharacterByName(challenge.challenger)
+	if challenger == nil {
+		s.messageService.SendConsoleMessage(char, "Tu oponente se ha desconectado.", outgoing.INFO)
+		return
+	}
+
+	if char.Dead {
+		s.messageService.SendConsoleMessage(char, "¡Estás muerto!", outgoing.INFO)
+		return
+	}
+
+	if !s.canDuel(char, challenger) {
+		return
+	}
+
+	d := &duel{
+		players:   [2]string{challenger.Name, char.Name},
+		mapID:     char.Position.Map,
+		countdown: s.globalBalance.DuelCountdown,
+		nextCount: time.Now(),
+	}
+
+	s.mu.Lock()
+	s.duels[duelKey(challenger.Name)] = d
+	s.duels[duelKey(char.Name)] = d
+	s.mu.Unlock()
+
+	slog.Info("Duel accepted", "challenger", challenger.Name, "opponent", char.Name)
+}
+
+func (s *DuelServiceImpl) Decline(char *model.Character) {
+	s.mu.Lock()
+	challenge := s.challenges[duelKey(char.Name)]
+	delete(s.challenges, duelKey(char.Name))
+	s.mu.Unlock()
+
+	if challenge == nil {
+		s.messageService.SendConsoleMessage(char, "No tienes desafíos pendientes.", outgoing.INFO)
+		return
+	}
+
+	s.messageService.SendConsoleMessage(char, "Has rechazado el duelo.", outgoing.INFO)
+	if challenger := s.userService.GetCharacterByName(challenge.challenger); challenger != nil {
+		s.messageService.SendConsoleMessage(challenger, fmt.Sprintf("%s ha rechazado tu duelo.", char.Name), outgoing.INFO)
+	}
+}
+
+func (s *DuelServiceImpl) IsConsensualFight(attacker, victim *model.Character) bool {
+	if s.mapService.IsFightZone(attacker.Position) && s.mapService.IsFightZone(victim.Position) {
+		return true
+	}
+
+	// Duels keep to the ground they were agreed on and follow the safe zone and PK map rules
+	for _, char := range []*model.Character{attacker, victim} {
+		if s.mapService.IsSafeZone(char.Position) || !s.mapService.IsPkMap(char.Position.Map) {
+			return false
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := s.duels[duelKey(attacker.Name)]
+	return d != nil && d.started && strings.EqualFold(d.opponent(attacker.Name), victim.Name) &&
+		attacker.Position.Map == d.mapID && victim.Position.Map == d.mapID
+}
+
+func (s *DuelServiceImpl) HandleDefeat(winner, loser *model.Character) bool {
+	inArena := s.mapService.IsFightZone(winner.Position) && s.mapService.IsFightZone(loser.Position)
+
+	s.mu.Lock()
+	d := s.duels[duelKey(loser.Name)]
+	isDuel := d != nil && d.started && strings.EqualFold(d.opponent(loser.Name), winner.Name)
+	if isDuel {
+		s.endDuel(d)
+	}
+	s.mu.Unlock()
+
+	if !isDuel && !inArena {
+		return false
+	}
+
+	// The loser is spared: no death, no item loss
+	loser.Hp = loser.MaxHp
+	s.effectService.Dispel(loser, model.EffectPoison)
+	s.effectService.Dispel(loser, model.EffectParalysis)
+	s.effectService.Dispel(loser, model.EffectImmobilization)
+
+	s.messageService.SendToArea(&outgoing.ConsoleMessagePacket{
+		Message: fmt.Sprintf("%s ha derrotado a %s.", winner.Name, loser.Name),
+		Font:    outgoing.INFOBOLD,
+	}, loser.Position)
+
+	if inArena {
+		s.messageService.WarpCharacter(loser, s.globalBalance.ArenaExit)
+	}
+
+	if conn := s.userService.GetConnection(loser); conn != nil {
+		conn.Send(outgoing.NewUpdateUserStatsPacket(loser))
+	}
+
+	kind := "DUELO"
+	if !isDuel {
+		kind = "ARENA"
+	}
+	s.record(winner, loser, kind)
+	return true
+}
+
+func (s *DuelServiceImpl) Forfeit(char *model.Character) {
+	key := duelKey(char.Name)
+
+	s.mu.Lock()
+	delete(s.challenges, key)
+	d := s.duels[key]
+	if d != nil {
+		s.endDuel(d)
+	}
+	s.mu.Unlock()
+
+	if d == nil {
+		return
+	}
+
+	winner := s.userService.GetCharacterByName(d.opponent(char.Name))
+	if winner == nil {
+		return
+	}
+
+	// Leaving during the countdown just calls the duel off
+	if !d.started {
+		s.messageService.SendConsoleMessage(winner, fmt.Sprintf("%s ha abandonado. El duelo ha sido cancelado.", char.Name), outgoing.INFO)
+		return
+	}
+
+	s.messageService.SendConsoleMessage(winner, fmt.Sprintf("%s ha abandonado el duelo. ¡Has ganado!", char.Name), outgoing.INFO)
+	s.record(winner, char, "ABANDONO")
+}
+
+// canDuel checks that neither player is dead, already dueling, too far away or where fighting
+// isn't allowed.
+func (s *DuelServiceImpl) canDuel(char, other *model.Character) bool {
+	if other.Dead {
+		s.messageService.SendConsoleMessage(char, fmt.Sprintf("%s está muerto.", other.Name), outgoing.INFO)
+		return false
+	}
+
+	if s.mapService.IsSafeZone(char.Position) || s.mapService.IsSafeZone(other.Position) || !s.mapService.IsPkMap(char.Position.Map) {
+		s.messageService.SendConsoleMessage(char, "No puedes batirte a duelo en zona segura.", outgoing.INFO)
+		return false
+	}
+
+	if char.Position.Map != other.Position.Map || char.Position.GetDistance(other.Position) > DuelMaxDistance {
+		s.messageService.SendConsoleMessage(char, fmt.Sprintf("Estás demasiado lejos de %s.", other.Name), outgoing.INFO)
+		return false
+	}
+
+	s.mu.Lock()
+	_, charBusy := s.duels[duelKey(char.Name)]
+	_, otherBusy := s.duels[duelKey(other.Name)]
+	s.mu.Unlock()
+
+	if charBusy {
+		s.messageService.SendConsoleMessage(char, "Ya estás en un duelo.", outgoing.INFO)
+		return false
+	}
+	if otherBusy {
+		s.messageService.SendConsoleMessage(char, fmt.Sprintf("%s ya está en un duelo.", other.Name), outgoing.INFO)
+		return false
+	}
+
+	return true
+}
+
+// ProcessCountdowns announces the seconds left to each agreed duel and starts the ones due.
+// It also ends the duels that can't go on, see endStaleDuels.
+func (s *DuelServiceImpl) ProcessCountdowns(now time.Time) {
+	s.endStaleDuels(now)
+
+	type announcement struct {
+		players [2]string
+		msg     string
+		font    outgoing.Font
+	}
+	var announcements []announcement
+
+	s.mu.Lock()
+	for key, d := range s.duels {
+		// Both players share the duel, handle it once
+		if d.started || key != duelKey(d.players[0]) || now.Before(d.nextCount) {
+			continue
+		}
+		if d.countdown > 0 {
+			announcements = append(announcements, announcement{d.players, fmt.Sprintf("El duelo comienza en %d...", d.countdown), outgoing.INFO})
+			d.countdown--
+			d.nextCount = now.Add(time.Second)
+		} else {
+			d.started = true
+			if s.globalBalance.DuelMaxDuration > 0 {
+				d.expires = now.Add(time.Duration(s.globalBalance.DuelMaxDuration) * time.Second)
+			}
+			announcements = append(announcements, announcement{d.players, "¡Que comience el duelo!", outgoing.INFOBOLD})
+		}
+	}
+	s.mu.Unlock()
+
+	for _, a := range announcements {
+		for _, name := range a.players {
+			if char := s.userService.GetCharacterByName(name); char != nil {
+				s.messageService.SendConsoleMessage(char, a.msg, a.font)
+			}
+		}
+	}
+}
+
+// endStaleDuels ends the duels one of whose players died, logged out or left the duel map, and
+// the ones that ran out of time. Nobody wins them.
+func (s *DuelServiceImpl) endStaleDuels(now time.Time) {
+	s.mu.Lock()
+	var duels []*duel
+	for key, d := range s.duels {
+		// Both players share the duel, handle it once
+		if key == duelKey(d.players[0]) {
+			duels = append(duels, d)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, d := range duels {
+		var players [2]*model.Character
+		reason := ""
+		for i, name := range d.players {
+			players[i] = s.userService.GetCharacterByName(name)
+			switch {
+			case reason != "":
+			case players[i] == nil:
+				reason = fmt.Sprintf("%s se ha desconectado", name)
+			case players[i].Dead:
+				reason = fmt.Sprintf("%s ha muerto", name)
+			case players[i].Position.Map != d.mapID:
+				reason = fmt.Sprintf("%s se ha alejado del lugar del duelo", name)
+			}
+		}
+		if reason == "" && !d.expires.IsZero() && now.After(d.expires) {
+			reason = "se ha agotado el tiempo, es un empate"
+		}
+		if reason == "" {
+			continue
+		}
+
+		s.mu.Lock()
+		current := s.duels[duelKey(d.players[0])] == d
+		if current {
+			s.endDuel(d)
+		}
+		s.mu.Unlock()
+
+		if !current {
+			continue
+		}
+		slog.Info("Duel ended", "players", d.players, "reason", reason)
+		for _, char := range players {
+			if char != nil {
+				s.messageService.SendConsoleMessage(char, fmt.Sprintf("El duelo ha terminado: %s.", reason), outgoing.INFO)
+			}
+		}
+	}
+}
+
+// endDuel must be called with s.mu held.
+func (s *DuelServiceImpl) endDuel(d *duel) {
+	delete(s.duels, duelKey(d.players[0]))
+	delete(s.duels, duelKey(d.players[1]))
+}
+
+func (s *DuelServiceImpl) record(winner, loser *model.Character, kind string) {
+	winner.DuelsWon++
+	loser.DuelsLost++
+
+	slog.Info("Duel finished", "kind", kind, "winner", winner.Name, "loser", loser.Name)
+
+	s.logMu.Lock()
+	defer s.logMu.Unlock()
+
+	os.MkdirAll("logs", 0755)
+	f, err := os.OpenFile(DuelLogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		slog.Error("Could not write duel log", "error", err)
+		return
+	}
+	defer f.Close()
+
+	timestamp := time.Now().Format("02/01/2006 15:04:05")
+	fmt.Fprintf(f, "%s %s %s gano a %s mapa %d\n", timestamp, kind, winner.Name, loser.Name, winner.Position.Map)
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"time"
+
+	"github.com/ao-go-server/internal/model"
+	"github.com/ao-go-server/internal/protocol/outgoing"
+	"github.com/ao-go-server/internal/utils"
+)
+
+type EffectServiceImpl struct {
+	userService    UserService
+	messageService MessageService
+}
+
+func NewEffectServiceImpl(userService UserService, messageService MessageService) EffectService {
+	return &EffectServiceImpl{
+		userService:    userService,
+		messageService: messageService,
+	}
+}
+
+// Apply follows the stack rules of each effect: attribute changes add up within their limits,
+// poison keeps its pace when applied again and the rest just start over.
+func (s *EffectServiceImpl) Apply(char *model.Character, effectType model.EffectType, magnitude int, duration time.Duration) {
+	wasParalyzed := isParalyzed(char)
+	now := time.Now()
+	attr, isAttribute := effectType.Attribute()
+
+	char.UpdateEffect(effectType, func(effect *model.Effect, active bool) {
+		if isAttribute {
+			stackAttribute(char, attr, effect, magnitude)
+		} else if effectType == model.EffectPoison {
+			if !active {
+				effect.NextTick = now.Add(model.PoisonInterval)
+			}
+			effect.Magnitude = max(effect.Magnitude, magnitude)
+		} else {
+			effect.Magnitude = magnitude
+		}
+
+		effect.Expires = time.Time{}
+		if duration > 0 {
+			effect.Expires = now.Add(duration)
+		}
+	})
+
+	if isAttribute {
+		s.sendStrengthAndDexterity(char)
+	}
+	s.syncParalysis(char, wasParalyzed)
+}
+
+// stackAttribute adds magnitude to the change an effect makes on an attribute. Raises stop
+// MaxAttributeBonus points over the original value and drops stop at MinAttributeValue.
+func stackAttribute(char *model.Character, attr model.Attribute, effect *model.Effect, magnitude int) {
+	base := int(char.OriginalAttributes[attr])
+	total := effect.Magnitude + magnitude
+	total = min(total, model.MaxAttributeBonus, 255-base)
+	total = max(total, min(0, model.MinAttributeValue-base))
+
+	effect.Magnitude = total
+	char.Attributes[attr] = byte(base + total)
+}
+
+func (s *EffectServiceImpl) Dispel(char *model.Character, effectType model.EffectType) bool {
+	wasParalyzed := isParalyzed(char)
+	if !char.RemoveEffect(effectType) {
+		return false
+	}
+
+	if _, ok := effectType.Attribute(); ok {
+		s.sendStrengthAndDexterity(char)
+	}
+
+	if effectType == model.EffectMimicry {
+		char.Body = char.UndisguisedBody
+		char.Head = char.UndisguisedHead
+		refreshAppearance(s.messageService, char)
+		s.messageService.SendConsoleMessage(char, "Recuperas tu apariencia normal.", outgoing.INFO)
+	}
+
+	s.syncParalysis(char, wasParalyzed)
+	return true
+}
+
+func (s *EffectServiceImpl) DispelAll(char *model.Character) {
+	for _, effect := range char.ActiveEffects() {
+		s.Dispel(char, effect.Type)
+	}
+}
+
+func (s *EffectServiceImpl) Tick(char *model.Character, now time.Time) bool {
+	poison, expired := char.DueEffects(now)
+
+	changed := false
+	if poison > 0 {
+		char.Hp -= utils.RandomNumber(1, poison)
+		changed = true
+		if char.Hp <= 0 {
+			s.messageService.HandleDeath(char, "Has muerto por el veneno.")
+			return true
+		}
+	}
+
+	for _, effectType := range expired {
+		if s.Dispel(char, effectType) {
+			s.announceExpiry(char, effectType)
+		}
+	}
+
+	return changed
+}
+
+func (s *EffectServiceImpl) announceExpiry(char *model.Character, effectType model.EffectType) {
+	switch effectType {
+	case model.EffectPoison:
+		s.messageService.SendConsoleMessage(char, "El veneno ha dejado de hacerte efecto.", outgoing.INFO)
+	case model.EffectParalysis, model.EffectImmobilization:
+		if !isParalyzed(char) {
+			s.messageService.SendConsoleMessage(char, "¡Has recuperado el movimiento!", outgoing.INFO)
+		}
+	case model.EffectStrength:
+		s.messageService.SendConsoleMessage(char, "Tu fuerza ha vuelto a la normalidad.", outgoing.INFO)
+	case model.EffectAgility:
+		s.messageService.SendConsoleMessage(char, "Tu agilidad ha vuelto a la normalidad.", outgoing.INFO)
+	}
+}
+
+// refreshAppearance shows the area the character's current looks. CharacterChange carries no
+// name, so the character is created again for the name over its head to follow disguises.
+func refreshAppearance(messageService MessageService, char *model.Character) {
+	messageService.SendToArea(&outgoing.CharacterChangePacket{Character: char}, char.Position)
+	messageService.SendToArea(&outgoing.CharacterCreatePacket{Character: char}, char.Position)
+}
+
+// isParalyzed tells whether the client shows the character paralyzed. Stuns are left out, the
+// client isn't told about them.
+func isParalyzed(char *model.Character) bool {
+	return char.HasEffect(model.EffectParalysis) || char.HasEffect(model.EffectImmobilization)
+}
+
+// syncParalysis toggles the client's paralysis when the effects changed it.
+func (s *EffectServiceImpl) syncParalysis(char *model.Character, wasParalyzed bool) {
+	if isParalyzed(char) == wasParalyzed {
+		return
+	}
+	if conn := s.userService.GetConnection(char); conn != nil {
+		conn.Send(&outgoing.ParalyzeOkPacket{})
+	}
+}
+
+func (s *EffectServiceImpl) sendStrengthAndDexterity(char *model.Character) {
+	if conn := s.userService.GetConnection(char); conn != nil {
+		conn.Send(&outgoing.UpdateStrengthAndDexterityPacket{
+			Strength:  char.Attributes[model.Strength],
+			Dexterity: char.Attributes[model.Dexterity],
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ao-go-server/internal/model"
+	"github.com/ao-go-server/internal/protocol/outgoing"
+)
+
+const GamblingLogFile = "logs/gambling.log"
+
+type gamblingWindow struct {
+	start   time.Time
+	wagered int
+}
+
+type GamblingServiceImpl struct {
+	npcService     NpcService
+	messageService MessageService
+	globalBalance  *model.GlobalBalanceConfig
+	windows        map[string]*gamblingWindow
+	mu             sync.Mutex
+	logMu          sync.Mutex
+}
+
+func NewGamblingServiceImpl(npcService NpcService, messageService MessageService, globalBalance *model.GlobalBalanceConfig) GamblingService {
+	return &GamblingServiceImpl{
+		npcService:     npcService,
+		messageService: messageService,
+		globalBalance:  globalBalance,
+		windows:        make(map[string]*gamblingWindow),
+	}
+}
+
+func (s *GamblingServiceImpl) Bet(char *model.Character, amount int) {
+	if char.Dead {
+		s.messageService.SendConsoleMessage(char, "¡Estás muerto!", outgoing.INFO)
+		return
+	}
+
+	npc := s.npcService.GetWorldNpcByIndex(char.TargetNPC)
+	if npc == nil || npc.NPC.Type != model.NTGambler {
+		s.messageService.SendConsoleMessage(char, "Primero tienes que seleccionar un timbero.", outgoing.INFO)
+		return
+	}
+
+	if npc.Position.Map != char.Position.Map || char.Position.GetDistance(npc.Position) > 10 {
+		s.messageService.SendConsoleMessage(char, "Estás demasiado lejos del timbero.", outgoing.INFO)
+		return
+	}
+
+	maxBet := s.globalBalance.GamblingMaxBet
+	if amount < 1 || (maxBet > 0 && amount > maxBet) {
+		s.npcSays(npc, fmt.Sprintf("El mínimo de apuesta es 1 y el máximo es %d.", maxBet))
+		return
+	}
+
+	if char.Gold < amount {
+		s.npcSays(npc, "No tienes esa cantidad.")
+		return
+	}
+
+	if !s.reserveWager(char.Name, amount) {
+		s.npcSays(npc, "Ya has apostado demasiado por esta hora, vuelve más tarde.")
+		return
+	}
+
+	// Even odds payout, the house edge is taken from the win probability
+	won := rand.Float64() < (1-s.globalBalance.GamblingHouseEdge)/2
+	if won {
+		char.Gold += amount
+		s.npcSays(npc, fmt.Sprintf("¡Felicidades! Has ganado %d monedas de oro.", amount))
+	} else {
+		char.Gold -= amount
+		s.npcSays(npc, fmt.Sprintf("Lo siento, has perdido %d monedas de oro.", amount))
+	}
+
+	if conn := s.messageService.UserService().GetConnection(char); conn != nil {
+		conn.Send(&outgoing.UpdateGoldPacket{Gold: char.Gold})
+	}
+
+	s.audit(char, npc, amount, won)
+}
+
+// reserveWager adds the amount to the player's hourly total, failing if it would exceed the cap.
+func (s *GamblingServiceImpl) reserveWager(name string, amount int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Windows that rolled over are dropped, so only this hour's gamblers are kept
+	now := time.Now()
+	for gambler, window := range s.windows {
+		if now.Sub(window.start) >= time.Hour {
+			delete(s.windows, gambler)
+		}
+	}
+
+	window, ok := s.windows[name]
+	if !ok {
+		window = &gamblingWindow{start: now}
+		s.windows[name] = window
+	}
+
+	hourlyCap := s.globalBalance.GamblingHourlyCap
+	if hourlyCap > 0 && window.wagered+amount > hourlyCap {
+		return false
+	}
+
+	window.wagered += amount
+	return true
+}
+
+func (s *GamblingServiceImpl) npcSays(npc *model.WorldNPC, msg string) {
+	s.messageService.SendToArea(&outgoing.ChatOverHeadPacket{
+		Message:   msg,
+		CharIndex: npc.Index,
+		R:         255,
+		G:         255,
+		B:         255,
+	}, npc.Position)
+}
+
+func (s *GamblingServiceImpl) audit(char *model.Character, npc *model.WorldNPC, amount int, won bool) {
+	result := "PERDIO"
+	if won {
+		result = "GANO"
+	}
+
+	slog.Info("Gamble", "name", char.Name, "npc", npc.NPC.ID, "amount", amount, "won", won, "gold", char.Gold)
+
+	s.logMu.Lock()
+	defer s.logMu.Unlock()
+
+	os.MkdirAll("logs", 0755)
+	f, err := os.OpenFile(GamblingLogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		slog.Error("Could not write gambling log", "error", err)
+		return
+	}
+	defer f.Close()
+
+	timestamp := time.Now().Format("02/01/2006 15:04:05")
+	fmt.Fprintf(f, "%s %s %s %d (oro: %d) mapa %d\n", timestamp, char.Name, result, amount, char.Gold, npc.Position.Map)
+}
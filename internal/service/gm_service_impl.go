@@ -3,7 +3,10 @@ package service
 import (
 	"fmt"
 	"log/slog"
+	"os"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ao-go-server/internal/model"
@@ -12,19 +15,45 @@ import (
 	"github.com/ao-go-server/internal/protocol/outgoing"
 )
 
+const GmLogFile = "logs/gm.log"
+
+// GmCreateItemAmount is the stack size of objects created with /CI.
+const GmCreateItemAmount = 100
+
+// EditChar options, as sent by the client's /MOD command.
+const (
+	EditCharGold        byte = 1
+	EditCharExp         byte = 2
+	EditCharBody        byte = 3
+	EditCharHead        byte = 4
+	EditCharLevel       byte = 7
+	EditCharSkills      byte = 9
+	EditCharSkillPoints byte = 10
+	EditCharAddGold     byte = 15
+)
+
 type GmServiceImpl struct {
 	userService    UserService
 	mapService     MapService
 	messageService MessageService
 	loginService   LoginService
+	npcService     NpcService
+	objectService  ObjectService
+	sosService     SosService
+	motdService    MotdService
+	logMu          sync.Mutex
 }
 
-func NewGmServiceImpl(userService UserService, mapService MapService, messageService MessageService, loginService LoginService) *GmServiceImpl {
+func NewGmServiceImpl(userService UserService, mapService MapService, messageService MessageService, loginService LoginService, npcService NpcService, objectService ObjectService, sosService SosService, motdService MotdService) *GmServiceImpl {
 	return &GmServiceImpl{
 		userService:    userService,
 		mapService:     mapService,
 		messageService: messageService,
 		loginService:   loginService,
+		npcService:     npcService,
+		objectService:  objectService,
+		sosService:     sosService,
+		motdService:    motdService,
 	}
 }
 
@@ -43,12 +72,52 @@ func (s *GmServiceImpl) HandleCommand(conn protocol.Connection, cmdID byte, buff
 		return s.handleServerTime(conn)
 	case 11: // /TELEP (WarpChar)
 		return s.handleWarpChar(conn, buffer)
+	case 12: // /SILENCIAR (Silence)
+		return s.handleSilence(user, buffer)
+	case 13: // /SHOW SOS
+		return s.handleSosShowList(conn)
+	case 14: // SOSDONE (SOSRemove)
+		return s.handleSosRemove(user, buffer)
 	case 15: // /IRA (GoToChar)
 		return s.handleGoToChar(conn, buffer)
+	case 16: // /INVISIBLE
+		return s.handleInvisible(user)
+	case 24: // /MOD (EditChar)
+		return s.handleEditChar(user, buffer)
+	case 28: // /INV (RequestCharInventory)
+		return s.handleCharInventory(user, buffer)
+	case 31: // /REVIVIR (ReviveChar)
+		return s.handleReviveChar(user, buffer)
 	case 32: // /ONLINEGM
 		return s.handleOnlineGM(conn)
 	case 33: // /DOBACKUP
 		return s.handleDoBackup(conn)
+	case 35: // /ECHAR (Kick)
+		return s.handleKick(user, buffer)
+	case 37: // /BAN (BanChar)
+		return s.handleBanChar(user, buffer)
+	case 38: // /UNBAN (UnbanChar)
+		return s.handleUnbanChar(user, buffer)
+	case 40: // /SUM (SummonChar)
+		return s.handleSummonChar(user, buffer)
+	case 41: // /CC (SpawnListRequest)
+		return s.handleSpawnListRequest(conn, user)
+	case 42: // SPA (SpawnCreature)
+		return s.handleSpawnCreature(user, buffer)
+	case 74: // /BANIP (BanIP)
+		return s.handleBanIP(user, buffer)
+	case 75: // /UNBANIP (UnbanIP)
+		return s.handleUnbanIP(user, buffer)
+	case 76: // /CI (CreateItem)
+		return s.handleCreateItem(user, buffer)
+	case 77: // /DEST (DestroyItems)
+		return s.handleDestroy(user)
+	case 87: // /MOTDCAMBIA (ChangeMOTD)
+		return s.handleChangeMotd(conn, user)
+	case 88: // ZMOTD (SetMOTD)
+		return s.handleSetMotd(user, buffer)
+	case 120: // /ATENDER (claim an SOS ticket)
+		return s.handleSosClaim(user, buffer)
 	default:
 		slog.Warn("GM sent unknown command", "gm", user.Name, "command_id", cmdID)
 	}
@@ -60,6 +129,634 @@ func (s *GmServiceImpl) handleDoBackup(conn protocol.Connection) (bool, error) {
 	return true, nil
 }
 
+func (s *GmServiceImpl) handleSosShowList(conn protocol.Connection) (bool, error) {
+	tickets := s.sosService.GetOpenTickets()
+	if len(tickets) == 0 {
+		conn.Send(&outgoing.ConsoleMessagePacket{Message: "No hay consultas pendientes.", Font: outgoing.INFO})
+		return true, nil
+	}
+
+	entries := make([]string, 0, len(tickets))
+	for _, t := range tickets {
+		entry := fmt.Sprintf("#%d %s (%d %d %d): %s", t.ID, t.Author, t.Position.Map, t.Position.X, t.Position.Y, t.Message)
+		if t.ClaimedBy != "" {
+			entry += fmt.Sprintf(" [%s]", t.ClaimedBy)
+		}
+		entries = append(entries, entry)
+		conn.Send(&outgoing.ConsoleMessagePacket{Message: entry, Font: outgoing.INFO})
+	}
+	conn.Send(&outgoing.ShowSosFormPacket{Entries: entries})
+	return true, nil
+}
+
+func (s *GmServiceImpl) handleSosClaim(user *model.Character, buffer *network.DataBuffer) (bool, error) {
+	ref, err := buffer.GetUTF8String()
+	if err != nil {
+		return false, nil
+	}
+
+	s.sosService.Claim(user, ref)
+	return true, nil
+}
+
+func (s *GmServiceImpl) handleSosRemove(user *model.Character, buffer *network.DataBuffer) (bool, error) {
+	ref, err := buffer.GetUTF8String()
+	if err != nil {
+		return false, nil
+	}
+
+	if s.sosService.Close(user.Name, ref) {
+		s.messageService.SendConsoleMessage(user, "Consulta cerrada.", outgoing.INFO)
+	} else {
+		s.messageService.SendConsoleMessage(user, "No existe esa consulta.", outgoing.INFO)
+	}
+	return true, nil
+}
+
+func (s *GmServiceImpl) handleSpawnListRequest(conn protocol.Connection, user *model.Character) (bool, error) {
+	if user.Privileges < model.PrivilegeSemiGod {
+		return true, nil
+	}
+
+	defs := s.npcService.GetSpawnList()
+	names := make([]string, 0, len(defs))
+	for _, def := range defs {
+		names = append(names, fmt.Sprintf("%d - %s", def.ID, def.Name))
+	}
+	conn.Send(&outgoing.SpawnListPacket{Names: names})
+	return true, nil
+}
+
+func (s *GmServiceImpl) handleSpawnCreature(user *model.Character, buffer *network.DataBuffer) (bool, error) {
+	idx, err := buffer.GetShort()
+	if err != nil {
+		return false, nil
+	}
+
+	if user.Privileges < model.PrivilegeSemiGod {
+		return true, nil
+	}
+
+	defs := s.npcService.GetSpawnList()
+	if idx < 1 || int(idx) > len(defs) {
+		return true, nil
+	}
+	def := defs[idx-1]
+
+	npc := s.mapService.SpawnNpcAt(def.ID, user.Position)
+	if npc == nil {
+		s.messageService.SendConsoleMessage(user, "No hay lugar para la criatura.", outgoing.INFO)
+		return true, nil
+	}
+	npc.Respawn = false
+	npc.SpawnedBy = user.Name
+	s.messageService.AreaService().BroadcastToArea(npc.Position, &outgoing.NpcCreatePacket{Npc: npc})
+
+	s.audit(user, "CREA NPC", fmt.Sprintf("%d (%s)", def.ID, def.Name), npc.Position)
+	return true, nil
+}
+
+// handleDestroy removes the NPC or object the GM last clicked on.
+func (s *GmServiceImpl) handleDestroy(user *model.Character) (bool, error) {
+	if user.Privileges < model.PrivilegeSemiGod {
+		return true, nil
+	}
+
+	if user.TargetNPC != 0 {
+		npc := s.npcService.GetWorldNpcByIndex(user.TargetNPC)
+		user.TargetNPC = 0
+		if npc == nil || npc.Position.Map != user.Position.Map {
+			return true, nil
+		}
+
+		npc.Respawn = false
+		s.messageService.SendToArea(&outgoing.CharacterRemovePacket{CharIndex: npc.Index}, npc.Position)
+		s.npcService.RemoveNPC(npc, s.mapService)
+		s.audit(user, "DESTRUYE NPC", fmt.Sprintf("%d (%s)", npc.NPC.ID, npc.NPC.Name), npc.Position)
+		return true, nil
+	}
+
+	if user.TargetObj != 0 && user.TargetObjMap == user.Position.Map {
+		pos := model.Position{Map: user.TargetObjMap, X: byte(user.TargetObjX), Y: byte(user.TargetObjY)}
+		user.TargetObj = 0
+
+		obj := s.mapService.GetObjectAt(pos)
+		if obj == nil {
+			return true, nil
+		}
+		if obj.Object.Type == model.OTTeleport || obj.Object.Type == model.OTDoor {
+			s.messageService.SendConsoleMessage(user, "No puedes destruir teleports ni puertas así.", outgoing.INFO)
+			return true, nil
+		}
+
+		s.mapService.RemoveObject(pos)
+		s.messageService.SendToArea(&outgoing.ObjectDeletePacket{X: pos.X, Y: pos.Y}, pos)
+		s.audit(user, "DESTRUYE OBJ", fmt.Sprintf("%d (%s) x%d", obj.Object.ID, obj.Object.Name, obj.Amount), pos)
+		return true, nil
+	}
+
+	s.messageService.SendConsoleMessage(user, "Primero haz click sobre la criatura u objeto a destruir.", outgoing.INFO)
+	return true, nil
+}
+
+// findOnlineTarget looks up a logged character by name ("YO" is the GM itself), telling the GM when it is offline.
+func (s *GmServiceImpl) findOnlineTarget(user *model.Character, name string) *model.Character {
+	if strings.EqualFold(name, "YO") {
+		return user
+	}
+
+	target := s.userService.GetCharacterByName(name)
+	if target == nil {
+		s.messageService.SendConsoleMessage(user, "Usuario offline.", outgoing.INFO)
+	}
+	return target
+}
+
+// outranks reports whether user may moderate target; nobody can act on someone of equal or higher rank.
+func (s *GmServiceImpl) outranks(user, target *model.Character) bool {
+	if target != user && target.Privileges >= user.Privileges {
+		s.messageService.SendConsoleMessage(user, "No puedes hacer eso con alguien de tu rango o superior.", outgoing.INFO)
+		return false
+	}
+	return true
+}
+
+func (s *GmServiceImpl) handleSilence(user *model.Character, buffer *network.DataBuffer) (bool, error) {
+	name, err := buffer.GetUTF8String()
+	if err != nil {
+		return false, nil
+	}
+
+	if user.Privileges < model.PrivilegeSemiGod {
+		return true, nil
+	}
+
+	target := s.findOnlineTarget(user, name)
+	if target == nil || !s.outranks(user, target) {
+		return true, nil
+	}
+
+	target.Silenced = !target.Silenced
+	if target.Silenced {
+		s.messageService.SendConsoleMessage(target, "Has sido silenciado por un administrador.", outgoing.INFO)
+		s.messageService.SendConsoleMessage(user, fmt.Sprintf("%s ha sido silenciado.", target.Name), outgoing.INFO)
+		s.audit(user, "SILENCIA", target.Name, target.Position)
+	} else {
+		s.messageService.SendConsoleMessage(target, "Ya puedes volver a hablar.", outgoing.INFO)
+		s.messageService.SendConsoleMessage(user, fmt.Sprintf("%s ya no está silenciado.", target.Name), outgoing.INFO)
+		s.audit(user, "QUITA SILENCIO", target.Name, target.Position)
+	}
+	return true, nil
+}
+
+func (s *GmServiceImpl) handleInvisible(user *model.Character) (bool, error) {
+	if user.Privileges < model.PrivilegeSemiGod {
+		return true, nil
+	}
+
+	user.AdminInvisible = !user.AdminInvisible
+	s.messageService.SendToArea(&outgoing.SetInvisiblePacket{CharIndex: user.CharIndex, Invisible: user.AdminInvisible}, user.Position)
+
+	if user.AdminInvisible {
+		s.messageService.SendConsoleMessage(user, "Ahora eres invisible para los usuarios.", outgoing.INFO)
+	} else {
+		s.messageService.SendConsoleMessage(user, "Has vuelto a ser visible.", outgoing.INFO)
+	}
+	return true, nil
+}
+
+func (s *GmServiceImpl) handleEditChar(user *model.Character, buffer *network.DataBuffer) (bool, error) {
+	name, err := buffer.GetUTF8String()
+	if err != nil {
+		return false, nil
+	}
+	option, err := buffer.Get()
+	if err != nil {
+		return false, nil
+	}
+	arg1, err := buffer.GetUTF8String()
+	if err != nil {
+		return false, nil
+	}
+	arg2, err := buffer.GetUTF8String()
+	if err != nil {
+		return false, nil
+	}
+
+	if !user.Privileges.IsGod() {
+		return true, nil
+	}
+
+	target := s.findOnlineTarget(user, name)
+	if target == nil || !s.outranks(user, target) {
+		return true, nil
+	}
+
+	value, err := strconv.Atoi(strings.TrimSpace(arg1))
+	if err != nil && option != EditCharSkills {
+		s.messageService.SendConsoleMessage(user, "Valor inválido.", outgoing.INFO)
+		return true, nil
+	}
+
+	switch option {
+	case EditCharGold:
+		target.Gold = max(value, 0)
+	case EditCharAddGold:
+		target.Gold = max(target.Gold+value, 0)
+	case EditCharExp:
+		target.Exp = max(value, 0)
+	case EditCharLevel:
+		target.Level = byte(min(max(value, 1), MaxLevel))
+	case EditCharSkillPoints:
+		target.SkillPoints = max(value, 0)
+	case EditCharBody:
+		target.Body = value
+		s.messageService.SendToArea(&outgoing.CharacterChangePacket{Character: target}, target.Position)
+	case EditCharHead:
+		target.Head = value
+		s.messageService.SendToArea(&outgoing.CharacterChangePacket{Character: target}, target.Position)
+	case EditCharSkills:
+		skill, err := strconv.Atoi(strings.TrimSpace(arg1))
+		points, err2 := strconv.Atoi(strings.TrimSpace(arg2))
+		if err != nil || err2 != nil || skill < int(model.Magic) || skill > int(model.Sailing) {
+			s.messageService.SendConsoleMessage(user, "Uso: /MOD <nick> SKILLS <número de skill> <valor>", outgoing.INFO)
+			return true, nil
+		}
+		target.Skills[model.Skill(skill)] = min(max(points, 0), 100)
+		if conn := s.userService.GetConnection(target); conn != nil {
+			conn.Send(&outgoing.SendSkillsPacket{Archetype: target.Archetype, Skills: target.Skills, SkillPoints: target.SkillPoints})
+		}
+	default:
+		s.messageService.SendConsoleMessage(user, "Comando no soportado.", outgoing.INFO)
+		return true, nil
+	}
+
+	if conn := s.userService.GetConnection(target); conn != nil {
+		conn.Send(outgoing.NewUpdateUserStatsPacket(target))
+	}
+
+	s.audit(user, "EDITA", fmt.Sprintf("%s opcion %d %s %s", target.Name, option, arg1, arg2), target.Position)
+	s.messageService.SendConsoleMessage(user, fmt.Sprintf("%s modificado.", target.Name), outgoing.INFO)
+	return true, nil
+}
+
+func (s *GmServiceImpl) handleCharInventory(user *model.Character, buffer *network.DataBuffer) (bool, error) {
+	name, err := buffer.GetUTF8String()
+	if err != nil {
+		return false, nil
+	}
+
+	if user.Privileges < model.PrivilegeSemiGod {
+		return true, nil
+	}
+
+	target := s.findOnlineTarget(user, name)
+	if target == nil {
+		return true, nil
+	}
+
+	s.messageService.SendConsoleMessage(user, fmt.Sprintf("Inventario de %s (oro: %d, banco: %d):", target.Name, target.Gold, target.BankGold), outgoing.INFO)
+	items := 0
+	for i, slot := range target.Inventory.Slots {
+		if slot.ObjectID == 0 {
+			continue
+		}
+
+		objName := "desconocido"
+		if obj := s.objectService.GetObject(slot.ObjectID); obj != nil {
+			objName = obj.Name
+		}
+		line := fmt.Sprintf("%d: %s (%d) x%d", i+1, objName, slot.ObjectID, slot.Amount)
+		if slot.Equipped {
+			line += " [equipado]"
+		}
+		s.messageService.SendConsoleMessage(user, line, outgoing.INFO)
+		items++
+	}
+	s.messageService.SendConsoleMessage(user, fmt.Sprintf("Total: %d objetos.", items), outgoing.INFO)
+	return true, nil
+}
+
+func (s *GmServiceImpl) handleReviveChar(user *model.Character, buffer *network.DataBuffer) (bool, error) {
+	name, err := buffer.GetUTF8String()
+	if err != nil {
+		return false, nil
+	}
+
+	if user.Privileges < model.PrivilegeSemiGod {
+		return true, nil
+	}
+
+	target := s.findOnlineTarget(user, name)
+	if target == nil {
+		return true, nil
+	}
+
+	if target.Dead {
+		s.messageService.HandleResurrection(target)
+	} else {
+		target.Hp = target.MaxHp
+		if conn := s.userService.GetConnection(target); conn != nil {
+			conn.Send(outgoing.NewUpdateUserStatsPacket(target))
+		}
+		s.messageService.SendConsoleMessage(target, "Has sido curado por un administrador.", outgoing.INFO)
+	}
+
+	s.audit(user, "REVIVE", target.Name, target.Position)
+	return true, nil
+}
+
+func (s *GmServiceImpl) handleKick(user *model.Character, buffer *network.DataBuffer) (bool, error) {
+	name, err := buffer.GetUTF8String()
+	if err != nil {
+		return false, nil
+	}
+
+	if user.Privileges < model.PrivilegeSemiGod {
+		return true, nil
+	}
+
+	target := s.findOnlineTarget(user, name)
+	if target == nil || target == user || !s.outranks(user, target) {
+		return true, nil
+	}
+
+	s.disconnectWithMessage(target, "Has sido echado del servidor.")
+	s.messageService.SendConsoleMessage(user, fmt.Sprintf("%s ha sido echado.", target.Name), outgoing.INFO)
+	s.audit(user, "ECHA", target.Name, target.Position)
+	return true, nil
+}
+
+func (s *GmServiceImpl) handleBanChar(user *model.Character, buffer *network.DataBuffer) (bool, error) {
+	name, err := buffer.GetUTF8String()
+	if err != nil {
+		return false, nil
+	}
+	reason, err := buffer.GetUTF8String()
+	if err != nil {
+		return false, nil
+	}
+
+	if user.Privileges < model.PrivilegeSemiGod {
+		return true, nil
+	}
+
+	if strings.EqualFold(name, user.Name) {
+		s.messageService.SendConsoleMessage(user, "No puedes banearte a ti mismo.", outgoing.INFO)
+		return true, nil
+	}
+
+	// Offline characters are ranked by the config, like they would be at login
+	target := s.userService.GetCharacterByName(name)
+	ranked := target
+	if ranked == nil {
+		ranked = &model.Character{Name: name, Privileges: s.loginService.PrivilegesOf(name)}
+	}
+	if !s.outranks(user, ranked) {
+		return true, nil
+	}
+
+	if err := s.loginService.LockAccount(name); err != nil {
+		s.messageService.SendConsoleMessage(user, "El personaje no existe.", outgoing.INFO)
+		return true, nil
+	}
+
+	if target != nil {
+		s.disconnectWithMessage(target, "Has sido baneado del servidor.")
+	}
+
+	s.messageService.SendConsoleMessage(user, fmt.Sprintf("%s ha sido baneado.", name), outgoing.INFO)
+	s.audit(user, "BANEA", fmt.Sprintf("%s motivo: %s", name, reason), user.Position)
+	return true, nil
+}
+
+func (s *GmServiceImpl) handleUnbanChar(user *model.Character, buffer *network.DataBuffer) (bool, error) {
+	name, err := buffer.GetUTF8String()
+	if err != nil {
+		return false, nil
+	}
+
+	if !user.Privileges.IsGod() {
+		return true, nil
+	}
+
+	if err := s.loginService.UnlockAccount(name); err != nil {
+		s.messageService.SendConsoleMessage(user, "El personaje no existe.", outgoing.INFO)
+		return true, nil
+	}
+
+	s.messageService.SendConsoleMessage(user, fmt.Sprintf("%s ha sido desbaneado.", name), outgoing.INFO)
+	s.audit(user, "DESBANEA", name, user.Position)
+	return true, nil
+}
+
+func (s *GmServiceImpl) handleSummonChar(user *model.Character, buffer *network.DataBuffer) (bool, error) {
+	name, err := buffer.GetUTF8String()
+	if err != nil {
+		return false, nil
+	}
+
+	if user.Privileges < model.PrivilegeSemiGod {
+		return true, nil
+	}
+
+	target := s.findOnlineTarget(user, name)
+	if target == nil || target == user {
+		return true, nil
+	}
+
+	s.messageService.WarpCharacter(target, user.Position)
+	newPos := target.Position
+	s.messageService.SendToArea(&outgoing.CreateFxPacket{CharIndex: target.CharIndex, FxID: 1, Loops: 0}, newPos)
+	s.messageService.SendToArea(&outgoing.PlayWavePacket{Wave: 2, X: newPos.X, Y: newPos.Y}, newPos)
+
+	s.messageService.SendConsoleMessage(target, fmt.Sprintf("%s te ha trasportado.", user.Name), outgoing.INFO)
+	s.audit(user, "SUMMONEA", target.Name, newPos)
+	return true, nil
+}
+
+func (s *GmServiceImpl) handleBanIP(user *model.Character, buffer *network.DataBuffer) (bool, error) {
+	byIP, err := buffer.GetBoolean()
+	if err != nil {
+		return false, nil
+	}
+
+	var ip, name string
+	if byIP {
+		if ip, err = readIP(buffer); err != nil {
+			return false, nil
+		}
+	} else if name, err = buffer.GetUTF8String(); err != nil {
+		return false, nil
+	}
+
+	reason, err := buffer.GetUTF8String()
+	if err != nil {
+		return false, nil
+	}
+
+	if !user.Privileges.IsGod() {
+		return true, nil
+	}
+
+	if !byIP {
+		target := s.findOnlineTarget(user, name)
+		if target == nil || target == user || !s.outranks(user, target) {
+			return true, nil
+		}
+		conn := s.userService.GetConnection(target)
+		if conn == nil {
+			return true, nil
+		}
+		ip = RemoteIP(conn)
+	}
+
+	if err := s.loginService.BanIP(ip); err != nil {
+		slog.Error("Failed to save banned IPs", "gm", user.Name, "error", err)
+		s.messageService.SendConsoleMessage(user, "No se pudo guardar el ban.", outgoing.INFO)
+		return true, nil
+	}
+
+	for _, conn := range s.userService.GetLoggedConnections() {
+		if RemoteIP(conn) == ip {
+			if char := conn.GetUser(); char != nil && char.Privileges.IsGM() {
+				continue
+			}
+			conn.Send(&outgoing.ErrorMessagePacket{Message: "Has sido baneado del servidor."})
+			conn.Disconnect()
+		}
+	}
+
+	s.messageService.SendConsoleMessage(user, fmt.Sprintf("La IP %s ha sido baneada.", ip), outgoing.INFO)
+	s.audit(user, "BANEA IP", fmt.Sprintf("%s %s motivo: %s", ip, name, reason), user.Position)
+	return true, nil
+}
+
+func (s *GmServiceImpl) handleUnbanIP(user *model.Character, buffer *network.DataBuffer) (bool, error) {
+	ip, err := readIP(buffer)
+	if err != nil {
+		return false, nil
+	}
+
+	if !user.Privileges.IsGod() {
+		return true, nil
+	}
+
+	removed, err := s.loginService.UnbanIP(ip)
+	if err != nil {
+		slog.Error("Failed to save banned IPs", "gm", user.Name, "error", err)
+	}
+	if !removed {
+		s.messageService.SendConsoleMessage(user, fmt.Sprintf("La IP %s no se encuentra en la lista de baneados.", ip), outgoing.INFO)
+		return true, nil
+	}
+
+	s.messageService.SendConsoleMessage(user, fmt.Sprintf("La IP %s se ha quitado de la lista de baneados.", ip), outgoing.INFO)
+	s.audit(user, "DESBANEA IP", ip, user.Position)
+	return true, nil
+}
+
+func (s *GmServiceImpl) handleCreateItem(user *model.Character, buffer *network.DataBuffer) (bool, error) {
+	objID, err := buffer.GetShort()
+	if err != nil {
+		return false, nil
+	}
+
+	if !user.Privileges.IsGod() {
+		return true, nil
+	}
+
+	obj := s.objectService.GetObject(int(objID))
+	if obj == nil {
+		s.messageService.SendConsoleMessage(user, "El objeto no existe.", outgoing.INFO)
+		return true, nil
+	}
+
+	pos := s.messageService.FindDropPosition(user.Position)
+	if pos == nil {
+		s.messageService.SendConsoleMessage(user, "No hay lugar para el objeto.", outgoing.INFO)
+		return true, nil
+	}
+
+	s.mapService.PutObject(*pos, &model.WorldObject{Object: obj, Amount: GmCreateItemAmount})
+	s.messageService.SendToArea(&outgoing.ObjectCreatePacket{X: pos.X, Y: pos.Y, GraphicIndex: int16(obj.GraphicIndex)}, *pos)
+
+	s.audit(user, "CREA OBJ", fmt.Sprintf("%d (%s) x%d", obj.ID, obj.Name, GmCreateItemAmount), *pos)
+	return true, nil
+}
+
+// disconnectWithMessage shows the reason to the player before closing its connection.
+func (s *GmServiceImpl) disconnectWithMessage(target *model.Character, msg string) {
+	conn := s.userService.GetConnection(target)
+	if conn == nil {
+		return
+	}
+	conn.Send(&outgoing.ErrorMessagePacket{Message: msg})
+	conn.Disconnect()
+}
+
+// readIP reads an IPv4 address sent as four bytes.
+func readIP(buffer *network.DataBuffer) (string, error) {
+	var ip [4]byte
+	for i := range ip {
+		b, err := buffer.Get()
+		if err != nil {
+			return "", err
+		}
+		ip[i] = b
+	}
+	return fmt.Sprintf("%d.%d.%d.%d", ip[0], ip[1], ip[2], ip[3]), nil
+}
+
+// audit keeps a record of what GMs create, destroy and moderate in the world.
+func (s *GmServiceImpl) audit(gm *model.Character, action string, detail string, pos model.Position) {
+	slog.Info("GM action", "gm", gm.Name, "action", action, "detail", detail, "pos", pos)
+
+	s.logMu.Lock()
+	defer s.logMu.Unlock()
+
+	os.MkdirAll("logs", 0755)
+	f, err := os.OpenFile(GmLogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		slog.Error("Could not write GM log", "error", err)
+		return
+	}
+	defer f.Close()
+
+	timestamp := time.Now().Format("02/01/2006 15:04:05")
+	fmt.Fprintf(f, "%s %s %s %s mapa %d %d %d\n", timestamp, gm.Name, action, detail, pos.Map, pos.X, pos.Y)
+}
+
+func (s *GmServiceImpl) handleChangeMotd(conn protocol.Connection, user *model.Character) (bool, error) {
+	if !user.Privileges.IsGod() {
+		return true, nil
+	}
+
+	conn.Send(&outgoing.ShowMotdEditionFormPacket{Text: strings.Join(s.motdService.GetMotd(), "\r\n")})
+	return true, nil
+}
+
+func (s *GmServiceImpl) handleSetMotd(user *model.Character, buffer *network.DataBuffer) (bool, error) {
+	text, err := buffer.GetUTF8String()
+	if err != nil {
+		return false, nil
+	}
+
+	if !user.Privileges.IsGod() {
+		return true, nil
+	}
+
+	if err := s.motdService.SetMotd(text); err != nil {
+		slog.Error("Failed to save MOTD", "gm", user.Name, "error", err)
+		s.messageService.SendConsoleMessage(user, "No se pudo guardar el MOTD.", outgoing.INFO)
+		return true, nil
+	}
+
+	slog.Info("MOTD changed", "gm", user.Name)
+	s.messageService.SendConsoleMessage(user, "Se ha cambiado el MOTD con éxito.", outgoing.INFO)
+	return true, nil
+}
+
 func (s *GmServiceImpl) handleGMMessage(sender *model.Character, buffer *network.DataBuffer) (bool, error) {
 	msg, err := buffer.GetUTF8String()
 	if err != nil {
@@ -122,31 +819,12 @@ func (s *GmServiceImpl) handleWarpChar(conn protocol.Connection, buffer *network
 		return true, nil
 	}
 
-	newPos := model.Position{Map: int(mapID), X: x, Y: y}
-
-	// Notify old area (User leaving)
-	s.messageService.SendToAreaButUser(&outgoing.CharacterRemovePacket{CharIndex: targetChar.CharIndex}, targetChar.Position, targetChar)
-
-	s.mapService.PutCharacterAtPos(targetChar, newPos)
-
-	targetConn := s.userService.GetConnection(targetChar)
-	if targetConn != nil {
-		targetConn.Send(&outgoing.ChangeMapPacket{MapId: int(mapID), Version: s.mapService.GetMap(int(mapID)).Version})
-		targetConn.Send(&outgoing.CharacterCreatePacket{Character: targetChar})
-		targetConn.Send(&outgoing.UserCharIndexInServerPacket{UserIndex: targetChar.CharIndex})
-		targetConn.Send(&outgoing.AreaChangedPacket{Position: newPos})
-		targetConn.Send(&outgoing.PosUpdatePacket{X: x, Y: y})
-
-		// Sync new area state to user (NPCs, Objects, Users)
-		s.messageService.AreaService().SendAreaState(targetChar)
-	}
-
-	// Notify new area (User entering)
-	s.messageService.SendToAreaButUser(&outgoing.CharacterCreatePacket{Character: targetChar}, newPos, targetChar)
+	s.messageService.WarpCharacter(targetChar, model.Position{Map: int(mapID), X: x, Y: y})
+	newPos := targetChar.Position
 
 	// FX and Sound
 	s.messageService.SendToArea(&outgoing.CreateFxPacket{CharIndex: targetChar.CharIndex, FxID: 1, Loops: 0}, newPos)
-	s.messageService.SendToArea(&outgoing.PlayWavePacket{Wave: 2, X: x, Y: y}, newPos)
+	s.messageService.SendToArea(&outgoing.PlayWavePacket{Wave: 2, X: newPos.X, Y: newPos.Y}, newPos)
 
 	conn.Send(&outgoing.ConsoleMessagePacket{Message: "Usuario transportado.", Font: outgoing.INFO})
 
@@ -195,6 +873,9 @@ func (s *GmServiceImpl) handleGoToChar(conn protocol.Connection, buffer *network
 
 	// Notify new area
 	s.messageService.SendToAreaButUser(&outgoing.CharacterCreatePacket{Character: user}, newPos, user)
+	if user.AdminInvisible {
+		s.messageService.SendToArea(&outgoing.SetInvisiblePacket{CharIndex: user.CharIndex, Invisible: true}, newPos)
+	}
 
 	// FX and Sound
 	s.messageService.SendToArea(&outgoing.CreateFxPacket{CharIndex: user.CharIndex, FxID: 1, Loops: 0}, newPos)
@@ -14,18 +14,20 @@ type ItemActionServiceImpl struct {
 	intervalService IntervalService
 	bodyService     BodyService
 	spellService    SpellService
+	effectService   EffectService
 
 	useBehaviors   map[model.ObjectType]ItemBehavior
 	equipBehaviors map[model.ObjectType]EquipBehavior
 }
 
-func NewItemActionServiceImpl(objSvc ObjectService, msgSvc MessageService, intSvc IntervalService, bodySvc BodyService, spellSvc SpellService) ItemActionService {
+func NewItemActionServiceImpl(objSvc ObjectService, msgSvc MessageService, intSvc IntervalService, bodySvc BodyService, spellSvc SpellService, effectSvc EffectService) ItemActionService {
 	s := &ItemActionServiceImpl{
 		objectService:   objSvc,
 		messageService:  msgSvc,
 		intervalService: intSvc,
 		bodyService:     bodySvc,
 		spellService:    spellSvc,
+		effectService:   effectSvc,
 		useBehaviors:    make(map[model.ObjectType]ItemBehavior),
 		equipBehaviors:  make(map[model.ObjectType]EquipBehavior),
 	}
@@ -236,12 +238,14 @@ func (s *ItemActionServiceImpl) registerDefaultBehaviors() {
 	shieldBehavior := &EquipGenericBehavior{s, model.OTShield}
 	helmetBehavior := &EquipGenericBehavior{s, model.OTHelmet}
 	ringBehavior := &EquipGenericBehavior{s, model.OTRing}
+	arrowBehavior := &EquipGenericBehavior{s, model.OTArrow}
 
 	s.equipBehaviors[model.OTWeapon] = weaponBehavior
 	s.equipBehaviors[model.OTArmor] = armorBehavior
 	s.equipBehaviors[model.OTShield] = shieldBehavior
 	s.equipBehaviors[model.OTHelmet] = helmetBehavior
 	s.equipBehaviors[model.OTRing] = ringBehavior
+	s.equipBehaviors[model.OTArrow] = arrowBehavior
 	s.equipBehaviors[model.OTBoat] = &BoatBehavior{s}
 }
 
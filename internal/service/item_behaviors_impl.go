@@ -57,73 +57,22 @@ type PotionBehavior struct {
 }
 
 func (b *PotionBehavior) Use(char *model.Character, slot int, obj *model.Object, connection protocol.Connection) {
-	if char.OriginalAttributes == nil {
-		char.OriginalAttributes = make(map[model.Attribute]byte)
-		for k, v := range char.Attributes {
-			char.OriginalAttributes[k] = v
-		}
-	}
-
 	switch obj.PotionType {
 	case 1: // Agility (Yellow)
-		base := int(char.OriginalAttributes[model.Dexterity])
 		modifier := utils.RandomNumber(obj.MinModifier, obj.MaxModifier)
-
-		current := int(char.Attributes[model.Dexterity])
-		if time.Now().After(char.AgilityEffectEnd) {
-			current = base
-		}
-
-		limit := base + 20
-		newVal := current + modifier
-		if newVal > limit {
-			newVal = limit
-		}
-		if newVal > 255 {
-			newVal = 255
-		}
-
-		char.Attributes[model.Dexterity] = byte(newVal)
-		char.AgilityEffectEnd = time.Now().Add(time.Duration(obj.Duration) * time.Second)
-
+		b.svc.effectService.Apply(char, model.EffectAgility, modifier, time.Duration(obj.Duration)*time.Second)
 		connection.Send(&outgoing.ConsoleMessagePacket{
 			Message: "¡Tu agilidad ha aumentado!",
 			Font:    outgoing.INFO,
 		})
-		connection.Send(&outgoing.UpdateStrengthAndDexterityPacket{
-			Strength:  char.Attributes[model.Strength],
-			Dexterity: char.Attributes[model.Dexterity],
-		})
 
 	case 2: // Strength (Green)
-		base := int(char.OriginalAttributes[model.Strength])
 		modifier := utils.RandomNumber(obj.MinModifier, obj.MaxModifier)
-
-		current := int(char.Attributes[model.Strength])
-		if time.Now().After(char.StrengthEffectEnd) {
-			current = base
-		}
-
-		limit := base + 20
-		newVal := current + modifier
-		if newVal > limit {
-			newVal = limit
-		}
-		if newVal > 255 {
-			newVal = 255
-		}
-
-		char.Attributes[model.Strength] = byte(newVal)
-		char.StrengthEffectEnd = time.Now().Add(time.Duration(obj.Duration) * time.Second)
-
+		b.svc.effectService.Apply(char, model.EffectStrength, modifier, time.Duration(obj.Duration)*time.Second)
 		connection.Send(&outgoing.ConsoleMessagePacket{
 			Message: "¡Tu fuerza ha aumentado!",
 			Font:    outgoing.INFO,
 		})
-		connection.Send(&outgoing.UpdateStrengthAndDexterityPacket{
-			Strength:  char.Attributes[model.Strength],
-			Dexterity: char.Attributes[model.Dexterity],
-		})
 
 	case 3: // HP
 		modifier := utils.RandomNumber(obj.MinModifier, obj.MaxModifier)
@@ -132,7 +81,7 @@ func (b *PotionBehavior) Use(char *model.Character, slot int, obj *model.Object,
 		modifier := utils.RandomNumber(obj.MinModifier, obj.MaxModifier)
 		char.Mana = utils.Min(char.MaxMana, char.Mana+modifier)
 	case 5: // Poison
-		char.Poisoned = false
+		b.svc.effectService.Dispel(char, model.EffectPoison)
 		connection.Send(&outgoing.ConsoleMessagePacket{
 			Message: "Te has curado del envenenamiento.",
 			Font:    outgoing.INFO,
@@ -197,13 +146,20 @@ func (b *EquipGenericBehavior) Use(char *model.Character, slot int, obj *model.O
 
 func (b *EquipGenericBehavior) ToggleEquip(char *model.Character, slot int, obj *model.Object, connection protocol.Connection) {
 	itemSlot := char.Inventory.GetSlot(slot)
+
+	// A disguise keeps its body, armor changes show once it wears off
+	body := &char.Body
+	if char.HasEffect(model.EffectMimicry) {
+		body = &char.UndisguisedBody
+	}
+
 	if itemSlot.Equipped {
 		itemSlot.Equipped = false
 		switch obj.Type {
 		case model.OTWeapon:
 			char.Weapon = 0
 		case model.OTArmor:
-			char.Body = b.svc.bodyService.GetBody(char.Race, char.Gender)
+			*body = b.svc.bodyService.GetBody(char.Race, char.Gender)
 		case model.OTShield:
 			char.Shield = 0
 		case model.OTHelmet:
@@ -226,7 +182,7 @@ func (b *EquipGenericBehavior) ToggleEquip(char *model.Character, slot int, obj
 			char.Weapon = int16(obj.EquippedWeaponGraphic)
 		case model.OTArmor:
 			if obj.EquippedArmorGraphic > 0 {
-				char.Body = obj.EquippedArmorGraphic
+				*body = obj.EquippedArmorGraphic
 			}
 		case model.OTShield:
 			char.Shield = int16(obj.EquippedWeaponGraphic)
@@ -281,6 +237,14 @@ func (b *ScrollBehavior) Use(char *model.Character, slot int, obj *model.Object,
 		}
 	}
 
+	if len(char.Spells) >= model.MaxSpells {
+		connection.Send(&outgoing.ConsoleMessagePacket{
+			Message: "No tienes espacio para más hechizos.",
+			Font:    outgoing.INFO,
+		})
+		return
+	}
+
 	spell := b.svc.spellService.GetSpell(obj.SpellIndex)
 	if spell == nil {
 		return
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -17,6 +18,7 @@ import (
 
 type LoginServiceImpl struct {
 	userRepo       persistence.UserRepository
+	banIPRepo      persistence.BanIPRepository
 	config         *config.Config
 	projectConfig  *config.ProjectConfig
 	userService    UserService
@@ -27,15 +29,25 @@ type LoginServiceImpl struct {
 	objectService  ObjectService
 	cityService    CityService
 	spellService   SpellService
+	duelService    DuelService
+	motdService    MotdService
+
+	raceModifiers      map[model.Race]*model.RaceModifiers
+	archetypeModifiers map[model.UserArchetype]*model.ArchetypeModifiers
+
+	banMu     sync.RWMutex
+	bannedIPs []string
 }
 
-func NewLoginServiceImpl(userRepo persistence.UserRepository,
+func NewLoginServiceImpl(userRepo persistence.UserRepository, banIPRepo persistence.BanIPRepository,
 	cfg *config.Config, projectCfg *config.ProjectConfig, userService UserService, mapService MapService,
 	bodyService BodyService, indexManager *CharacterIndexManager,
 	messageService MessageService, objectService ObjectService, cityService CityService,
-	spellService SpellService) LoginService {
+	spellService SpellService, duelService DuelService, motdService MotdService,
+	raceModifiers map[model.Race]*model.RaceModifiers, archetypeModifiers map[model.UserArchetype]*model.ArchetypeModifiers) LoginService {
 	return &LoginServiceImpl{
 		userRepo:       userRepo,
+		banIPRepo:      banIPRepo,
 		config:         cfg,
 		projectConfig:  projectCfg,
 		userService:    userService,
@@ -46,6 +58,11 @@ func NewLoginServiceImpl(userRepo persistence.UserRepository,
 		objectService:  objectService,
 		cityService:    cityService,
 		spellService:   spellService,
+		duelService:    duelService,
+		motdService:    motdService,
+
+		raceModifiers:      raceModifiers,
+		archetypeModifiers: archetypeModifiers,
 	}
 }
 
@@ -58,6 +75,10 @@ func (s *LoginServiceImpl) ConnectNewCharacter(conn protocol.Connection, nick, p
 		return err
 	}
 
+	if err := s.checkIPBan(conn); err != nil {
+		return err
+	}
+
 	if !s.config.CharacterCreationEnabled {
 		return fmt.Errorf("la creación de personajes está deshabilitada")
 	}
@@ -69,6 +90,12 @@ func (s *LoginServiceImpl) ConnectNewCharacter(conn protocol.Connection, nick, p
 
 	race := model.Race(raceId)
 	gender := model.Gender(genderId)
+	archetype := model.UserArchetype(archetypeId)
+
+	raceMods, err := s.validateRaceAndClass(race, gender, archetype)
+	if err != nil {
+		return err
+	}
 
 	if !s.bodyService.IsValidHead(int(headId), race, gender) {
 		return fmt.Errorf("cabeza inválida")
@@ -88,13 +115,15 @@ func (s *LoginServiceImpl) ConnectNewCharacter(conn protocol.Connection, nick, p
 		return fmt.Errorf("debe tirar los dados antes de crear un personaje")
 	}
 
+	applyRaceModifiers(attrs, raceMods)
+
 	city, ok := s.cityService.GetCity(int(cityId))
 	if !ok {
 		city = model.City{Map: 1, X: 50, Y: 50} // Default city placeholder
 	}
 
 	acc, char, err := s.userRepo.CreateAccountAndCharacter(nick, password, mail,
-		race, gender, model.UserArchetype(archetypeId),
+		race, gender, archetype,
 		int(headId), city, attrs)
 
 	if err != nil {
@@ -104,9 +133,44 @@ func (s *LoginServiceImpl) ConnectNewCharacter(conn protocol.Connection, nick, p
 	s.ensureValidCharacterState(char, body)
 
 	s.finalizeLogin(conn, acc, char)
+	s.messageService.SendConsoleMessage(char, fmt.Sprintf("Tus atributos, con los modificadores de tu raza: Fuerza %d, Agilidad %d, Inteligencia %d, Carisma %d, Constitución %d.",
+		attrs[model.Strength], attrs[model.Dexterity], attrs[model.Intelligence], attrs[model.Charisma], attrs[model.Constitution]), outgoing.INFO)
 	return nil
 }
 
+// validateRaceAndClass checks the race, gender and class sent by the client for a new character
+// and returns the modifiers of the race.
+func (s *LoginServiceImpl) validateRaceAndClass(race model.Race, gender model.Gender, archetype model.UserArchetype) (*model.RaceModifiers, error) {
+	raceMods, ok := s.raceModifiers[race]
+	if !ok {
+		return nil, fmt.Errorf("raza inválida")
+	}
+	if gender != model.Male && gender != model.Female {
+		return nil, fmt.Errorf("género inválido")
+	}
+	if _, ok := s.archetypeModifiers[archetype]; !ok {
+		return nil, fmt.Errorf("clase inválida")
+	}
+	if slices.Contains(raceMods.ForbiddenArchetypes, archetype) {
+		return nil, fmt.Errorf("tu raza no puede elegir esa clase")
+	}
+	return raceMods, nil
+}
+
+// applyRaceModifiers adjusts the rolled attributes by the race modifiers, never below 1.
+func applyRaceModifiers(attrs map[model.Attribute]byte, mods *model.RaceModifiers) {
+	bonus := map[model.Attribute]int{
+		model.Strength:     mods.Strength,
+		model.Dexterity:    mods.Dexterity,
+		model.Intelligence: mods.Intelligence,
+		model.Charisma:     mods.Charisma,
+		model.Constitution: mods.Constitution,
+	}
+	for attr, b := range bonus {
+		attrs[attr] = byte(max(1, int(attrs[attr])+b))
+	}
+}
+
 // ConnectExistingCharacter handles the login of an existing character.
 // It follows the protocol described in login_existing_char.txt strictly for logic.
 func (s *LoginServiceImpl) ConnectExistingCharacter(conn protocol.Connection, nick, password, version, clientHash string) error {
@@ -132,6 +196,9 @@ func (s *LoginServiceImpl) ConnectExistingCharacter(conn protocol.Connection, ni
 	if err := s.checkBan(acc); err != nil {
 		return err
 	}
+	if err := s.checkIPBan(conn); err != nil {
+		return err
+	}
 
 	// 5. Concurrency Check (Already logged in)
 	if err := s.checkAlreadyLoggedIn(nick); err != nil {
@@ -209,6 +276,14 @@ func (s *LoginServiceImpl) checkBan(acc *model.Account) error {
 	return nil
 }
 
+// checkIPBan rejects connections coming from a banned IP address.
+func (s *LoginServiceImpl) checkIPBan(conn protocol.Connection) error {
+	if s.IsIPBanned(RemoteIP(conn)) {
+		return fmt.Errorf("acceso denegado: tu IP se encuentra baneada")
+	}
+	return nil
+}
+
 // checkAlreadyLoggedIn ensures the character is not already in the game.
 func (s *LoginServiceImpl) checkAlreadyLoggedIn(nick string) error {
 	if s.userService.IsUserLoggedIn(nick) {
@@ -250,42 +325,50 @@ func (s *LoginServiceImpl) finalizeLogin(conn protocol.Connection, acc *model.Ac
 	// Send Handshake / Game State
 	s.sendInitialGameState(conn, char)
 
+	// Paralysis saved with the character, the client only learns about it through the toggle
+	if isParalyzed(char) {
+		conn.Send(&outgoing.ParalyzeOkPacket{})
+	}
+
 	// Notify others
 	s.messageService.SendToAreaButUser(&outgoing.CharacterCreatePacket{Character: char}, char.Position, char)
 	s.messageService.AreaService().SendAreaState(char)
 
+	s.motdService.SendMotd(char)
+
 	slog.Info("User logged in", "name", char.Name, "pos", char.Position, "privs", char.Privileges)
 }
 
 func (s *LoginServiceImpl) determinePrivileges(char *model.Character) {
-	name := strings.ToUpper(char.Name)
-	char.Privileges = model.PrivilegeUser
+	char.Privileges = s.PrivilegesOf(char.Name)
+}
+
+// PrivilegesOf returns the rank the server config gives a character, online or not.
+func (s *LoginServiceImpl) PrivilegesOf(nick string) model.PrivilegeLevel {
+	name := strings.ToUpper(nick)
 
 	// Check in descending order of power
 	for _, admin := range s.config.Gods {
 		if name == admin {
-			char.Privileges = model.PrivilegeGod
-			return
+			return model.PrivilegeGod
 		}
 	}
 	for _, admin := range s.config.SemiGods {
 		if name == admin {
-			char.Privileges = model.PrivilegeSemiGod
-			return
+			return model.PrivilegeSemiGod
 		}
 	}
 	for _, admin := range s.config.Counselors {
 		if name == admin {
-			char.Privileges = model.PrivilegeCounselor
-			return
+			return model.PrivilegeCounselor
 		}
 	}
 	for _, admin := range s.config.RoleMasters {
 		if name == admin {
-			char.Privileges = model.PrivilegeRoleMaster
-			return
+			return model.PrivilegeRoleMaster
 		}
 	}
+	return model.PrivilegeUser
 }
 
 func (s *LoginServiceImpl) sendInitialGameState(conn protocol.Connection, char *model.Character) {
@@ -389,6 +472,7 @@ func (s *LoginServiceImpl) OnUserDisconnect(conn protocol.Connection) {
 	char := conn.GetUser()
 	if char != nil {
 		slog.Info("User disconnected, saving...", "name", char.Name)
+		s.duelService.Forfeit(char)
 		s.SavePlayer(char.Name)
 
 		// Broadcast removal
@@ -419,6 +503,66 @@ func (s *LoginServiceImpl) UnlockAccount(nick string) error {
 	return s.userRepo.SaveAccount(acc)
 }
 
+func (s *LoginServiceImpl) LoadBannedIPs() error {
+	ips, err := s.banIPRepo.Load()
+	if err != nil {
+		return err
+	}
+
+	s.banMu.Lock()
+	s.bannedIPs = ips
+	s.banMu.Unlock()
+
+	slog.Info("Banned IPs loaded", "count", len(ips))
+	return nil
+}
+
+func (s *LoginServiceImpl) BanIP(ip string) error {
+	s.banMu.Lock()
+	defer s.banMu.Unlock()
+
+	for _, banned := range s.bannedIPs {
+		if banned == ip {
+			return nil
+		}
+	}
+	s.bannedIPs = append(s.bannedIPs, ip)
+	return s.banIPRepo.Save(s.bannedIPs)
+}
+
+// UnbanIP lifts the ban on ip, reporting false if it was not banned.
+func (s *LoginServiceImpl) UnbanIP(ip string) (bool, error) {
+	s.banMu.Lock()
+	defer s.banMu.Unlock()
+
+	for i, banned := range s.bannedIPs {
+		if banned == ip {
+			s.bannedIPs = append(s.bannedIPs[:i], s.bannedIPs[i+1:]...)
+			return true, s.banIPRepo.Save(s.bannedIPs)
+		}
+	}
+	return false, nil
+}
+
+func (s *LoginServiceImpl) IsIPBanned(ip string) bool {
+	s.banMu.RLock()
+	defer s.banMu.RUnlock()
+
+	for _, banned := range s.bannedIPs {
+		if banned == ip {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *LoginServiceImpl) GetBannedIPs() []string {
+	s.banMu.RLock()
+	defer s.banMu.RUnlock()
+
+	return append([]string(nil), s.bannedIPs...)
+}
+
 func (s *LoginServiceImpl) ResetPassword(nick string, newPassword string) error {
 	acc, err := s.userRepo.Get(nick)
 	if err != nil {
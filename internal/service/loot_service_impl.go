@@ -0,0 +1,81 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"time"
+
+	"github.com/ao-go-server/internal/config"
+	"github.com/ao-go-server/internal/model"
+	"github.com/ao-go-server/internal/protocol/outgoing"
+	"github.com/ao-go-server/internal/utils"
+)
+
+type LootServiceImpl struct {
+	objectService  ObjectService
+	mapService     MapService
+	messageService MessageService
+	userService    UserService
+	config         *config.Config
+	globalBalance  *model.GlobalBalanceConfig
+}
+
+func NewLootServiceImpl(objectService ObjectService, mapService MapService, messageService MessageService, userService UserService, cfg *config.Config, globalBalance *model.GlobalBalanceConfig) LootService {
+	return &LootServiceImpl{
+		objectService:  objectService,
+		mapService:     mapService,
+		messageService: messageService,
+		userService:    userService,
+		config:         cfg,
+		globalBalance:  globalBalance,
+	}
+}
+
+func (s *LootServiceImpl) DropNpcLoot(killer *model.Character, npc *model.WorldNPC) {
+	if npc.NPC.GiveGold > 0 {
+		gold := int(float64(npc.NPC.GiveGold) * s.config.GoldMultiplier)
+		killer.Gold += gold
+		s.messageService.SendConsoleMessage(killer, fmt.Sprintf("¡Has ganado %d monedas de oro!", gold), outgoing.INFO)
+		if conn := s.userService.GetConnection(killer); conn != nil {
+			conn.Send(&outgoing.UpdateGoldPacket{Gold: killer.Gold})
+		}
+	}
+
+	ownerUntil := time.Now().Add(time.Duration(s.globalBalance.LootOwnershipTime) * time.Second)
+
+	for _, drop := range npc.NPC.Drops {
+		if rand.Float64()*100 >= drop.Chance {
+			continue
+		}
+
+		obj := s.objectService.GetObject(drop.ObjectID)
+		if obj == nil {
+			continue
+		}
+
+		amount := utils.RandomNumber(drop.MinAmount, drop.MaxAmount)
+		if obj.Type == model.OTMoney {
+			amount = int(float64(amount) * s.config.GoldMultiplier)
+		}
+
+		// Spread drops around the NPC so they don't overwrite each other
+		dropPos := s.messageService.FindDropPosition(npc.Position)
+		if dropPos == nil {
+			slog.Debug("No free tile for NPC drop", "npc", npc.NPC.ID, "object", obj.ID, "pos", npc.Position)
+			continue
+		}
+
+		s.mapService.PutObject(*dropPos, &model.WorldObject{
+			Object:     obj,
+			Amount:     amount,
+			Owner:      killer.Name,
+			OwnerUntil: ownerUntil,
+		})
+		s.messageService.SendToArea(&outgoing.ObjectCreatePacket{
+			X:            dropPos.X,
+			Y:            dropPos.Y,
+			GraphicIndex: int16(obj.GraphicIndex),
+		}, *dropPos)
+	}
+}
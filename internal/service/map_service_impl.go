@@ -175,6 +175,7 @@ func (s *MapServiceImpl) UnloadMap(id int) {
 		npc.Respawn = false
 		s.npcService.RemoveNPC(npc, s)
 	}
+	s.npcService.CancelRespawns(id)
 
 	delete(s.maps, id)
 }
@@ -237,6 +238,7 @@ func (s *MapServiceImpl) resolveMapEntities(m *model.Map) {
 			if worldNpc != nil {
 				tile.NPC = worldNpc
 				m.AddNpc(worldNpc)
+				s.assignSpawnRegion(worldNpc)
 				npcsFound++
 			} else {
 				slog.Warn("Could not resolve NPC", "map_id", m.Id, "npc_id", tile.NPCID, "tile", i)
@@ -244,6 +246,8 @@ func (s *MapServiceImpl) resolveMapEntities(m *model.Map) {
 		}
 	}
 
+	s.populateSpawnRegions(m)
+
 	if objectsFound > 0 || npcsFound > 0 {
 		// slog.Debug("Resolved entities on ground", "map_id", m.Id, "objects", objectsFound, "npcs", npcsFound)
 	}
@@ -425,7 +429,7 @@ func (s *MapServiceImpl) MoveCharacterTo(char *model.Character, heading model.He
 		newPos.X--
 	}
 
-	if char.Paralyzed || char.Immobilized {
+	if !char.CanMove() {
 		return char.Position, false
 	}
 
@@ -500,6 +504,24 @@ func (s *MapServiceImpl) IsSafeZone(pos model.Position) bool {
 	return tile.Trigger == model.TriggerSafeZone
 }
 
+func (s *MapServiceImpl) IsFightZone(pos model.Position) bool {
+	m := s.GetMap(pos.Map)
+	if m == nil {
+		return false
+	}
+	tile := m.GetTile(int(pos.X), int(pos.Y))
+	return tile.Trigger == model.TriggerFightZone
+}
+
+func (s *MapServiceImpl) IsAntiPicket(pos model.Position) bool {
+	m := s.GetMap(pos.Map)
+	if m == nil {
+		return false
+	}
+	tile := m.GetTile(int(pos.X), int(pos.Y))
+	return tile.Trigger == model.TriggerAntiPicket
+}
+
 func (s *MapServiceImpl) IsPkMap(mapID int) bool {
 	m := s.GetMap(mapID)
 	if m == nil {
@@ -587,3 +609,129 @@ func (s *MapServiceImpl) SpawnNpcInMap(npcID int, mapID int) *model.WorldNPC {
 	}
 	return nil
 }
+
+// SpawnNpcAt spawns the NPC on pos, or on the closest free tile around it.
+func (s *MapServiceImpl) SpawnNpcAt(npcID int, pos model.Position) *model.WorldNPC {
+	m := s.GetMap(pos.Map)
+	if m == nil {
+		return nil
+	}
+
+	for radius := 0; radius <= 5; radius++ {
+		for dy := -radius; dy <= radius; dy++ {
+			for dx := -radius; dx <= radius; dx++ {
+				// Only the ring at this radius, inner tiles were already tried
+				if dx != -radius && dx != radius && dy != -radius && dy != radius {
+					continue
+				}
+				x, y := int(pos.X)+dx, int(pos.Y)+dy
+				if s.canSpawnAt(m, x, y) {
+					return s.placeNpc(m, npcID, x, y)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// SpawnNpcInRegion spawns the NPC on a random free tile of the region, unless the
+// region already holds its maximum population of that NPC.
+func (s *MapServiceImpl) SpawnNpcInRegion(npcID int, region *model.SpawnRegion) *model.WorldNPC {
+	m := s.GetMap(region.Map)
+	if m == nil {
+		return nil
+	}
+
+	entry := region.GetNpc(npcID)
+	if entry == nil || s.countRegionNpcs(m, region, npcID) >= entry.Max {
+		return nil
+	}
+	return s.spawnInRegion(m, npcID, region)
+}
+
+func (s *MapServiceImpl) spawnInRegion(m *model.Map, npcID int, region *model.SpawnRegion) *model.WorldNPC {
+	for i := 0; i < 100; i++ {
+		x := int(region.X1) + rand.Intn(int(region.X2-region.X1)+1)
+		y := int(region.Y1) + rand.Intn(int(region.Y2-region.Y1)+1)
+		if !s.canSpawnAt(m, x, y) {
+			continue
+		}
+
+		worldNpc := s.placeNpc(m, npcID, x, y)
+		if worldNpc != nil {
+			worldNpc.SpawnRegion = region
+			worldNpc.Respawn = true
+		}
+		return worldNpc
+	}
+	return nil
+}
+
+// populateSpawnRegions tops every spawn region of the map up to its population caps.
+func (s *MapServiceImpl) populateSpawnRegions(m *model.Map) {
+	for _, region := range s.mapDAO.GetSpawnRegions(m.Id) {
+		for _, entry := range region.Npcs {
+			for n := s.countRegionNpcs(m, region, entry.NpcID); n < entry.Max; n++ {
+				if s.spawnInRegion(m, entry.NpcID, region) == nil {
+					slog.Warn("Could not fill spawn region", "map_id", m.Id, "region", region.Name, "npc_id", entry.NpcID)
+					break
+				}
+			}
+		}
+	}
+}
+
+// assignSpawnRegion links an NPC placed by the map file to the spawn region it stands in, if any.
+func (s *MapServiceImpl) assignSpawnRegion(npc *model.WorldNPC) {
+	for _, region := range s.mapDAO.GetSpawnRegions(npc.Position.Map) {
+		if region.Contains(npc.Position) && region.GetNpc(npc.NPC.ID) != nil {
+			npc.SpawnRegion = region
+			npc.Respawn = true
+			return
+		}
+	}
+}
+
+func (s *MapServiceImpl) countRegionNpcs(m *model.Map, region *model.SpawnRegion, npcID int) int {
+	count := 0
+	m.View(func(m *model.Map) {
+		for _, npc := range m.GetNpcs() {
+			if npc.SpawnRegion == region && npc.NPC.ID == npcID {
+				count++
+			}
+		}
+	})
+	return count
+}
+
+func (s *MapServiceImpl) canSpawnAt(m *model.Map, x, y int) bool {
+	if !s.IsInPlayableArea(x, y) {
+		return false
+	}
+
+	tile := m.GetTile(x, y)
+	if tile.Trigger == model.TriggerInvalidPosition || tile.TileExit != nil {
+		return false
+	}
+
+	hasBridge := tile.Layer2 > 0
+	if tile.IsWater && !hasBridge {
+		return false
+	}
+
+	return !tile.Blocked && tile.Character == nil && tile.NPC == nil
+}
+
+func (s *MapServiceImpl) placeNpc(m *model.Map, npcID int, x, y int) *model.WorldNPC {
+	pos := model.Position{X: byte(x), Y: byte(y), Map: m.Id}
+	worldNpc := s.npcService.SpawnNpc(npcID, pos)
+	if worldNpc == nil {
+		return nil
+	}
+
+	m.Modify(func(m *model.Map) {
+		m.AddNpc(worldNpc)
+		m.GetTile(x, y).NPC = worldNpc
+	})
+	return worldNpc
+}
@@ -41,13 +41,14 @@ func (s *MessageServiceImpl) HandleDeath(char *model.Character, message string)
 
 	isSafe := s.mapService.IsSafeZone(char.Position)
 	isPkMap := s.mapService.IsPkMap(char.Position.Map)
-	shouldDropItems := !isSafe && isPkMap
+	// Arena deaths never cost items
+	shouldDropItems := !isSafe && isPkMap && !s.mapService.IsFightZone(char.Position)
 
 	char.Dead = true
 	char.Hp = 0
-	char.Poisoned = false
-	char.Paralyzed = false
-	char.Immobilized = false
+	disguised := char.HasEffect(model.EffectMimicry)
+	wasParalyzed := isParalyzed(char)
+	char.ClearEffects()
 	char.Body = 8   // Ghost
 	char.Head = 500 // Ghost head
 	char.Weapon = 0
@@ -70,7 +71,7 @@ func (s *MessageServiceImpl) HandleDeath(char *model.Character, message string)
 
 		// Drop logic
 		if shouldDropItems && !obj.Newbie && !obj.NoDrop {
-			dropPos := s.findDropPosition(char.Position)
+			dropPos := s.FindDropPosition(char.Position)
 			if dropPos != nil {
 				worldObj := &model.WorldObject{
 					Object: obj,
@@ -99,6 +100,10 @@ func (s *MessageServiceImpl) HandleDeath(char *model.Character, message string)
 	}
 
 	if conn != nil {
+		// Toggle the client's paralysis off, ghosts walk
+		if wasParalyzed {
+			conn.Send(&outgoing.ParalyzeOkPacket{})
+		}
 		conn.Send(outgoing.NewUpdateUserStatsPacket(char))
 		if message != "" {
 			conn.Send(&outgoing.ConsoleMessagePacket{
@@ -129,7 +134,11 @@ func (s *MessageServiceImpl) HandleDeath(char *model.Character, message string)
 	}
 
 	// Broadcast character appearance change (ghost)
-	s.SendToArea(&outgoing.CharacterChangePacket{Character: char}, char.Position)
+	if disguised {
+		refreshAppearance(s, char)
+	} else {
+		s.SendToArea(&outgoing.CharacterChangePacket{Character: char}, char.Position)
+	}
 }
 
 func (s *MessageServiceImpl) checkDropPos(center model.Position, dx, dy int) *model.Position {
@@ -152,7 +161,8 @@ func (s *MessageServiceImpl) checkDropPos(center model.Position, dx, dy int) *mo
 	return nil
 }
 
-func (s *MessageServiceImpl) findDropPosition(startPos model.Position) *model.Position {
+// FindDropPosition returns the closest tile (up to 3 tiles away) where an object can be dropped.
+func (s *MessageServiceImpl) FindDropPosition(startPos model.Position) *model.Position {
 	// 1. Check center
 	if pos := s.checkDropPos(startPos, 0, 0); pos != nil {
 		return pos
@@ -207,6 +217,54 @@ func (s *MessageServiceImpl) HandleResurrection(char *model.Character) {
 	s.SendToArea(&outgoing.CharacterChangePacket{Character: char}, char.Position)
 }
 
+// WarpCharacter moves a character to the closest free tile around pos, updating its client and both areas.
+func (s *MessageServiceImpl) WarpCharacter(char *model.Character, pos model.Position) {
+	dest := pos
+	for radius := 0; radius <= 5; radius++ {
+		found := false
+		for dx := -radius; dx <= radius && !found; dx++ {
+			for dy := -radius; dy <= radius; dy++ {
+				x, y := int(pos.X)+dx, int(pos.Y)+dy
+				if s.mapService.IsTileEmpty(pos.Map, x, y) {
+					dest = model.Position{Map: pos.Map, X: byte(x), Y: byte(y)}
+					found = true
+					break
+				}
+			}
+		}
+		if found {
+			break
+		}
+	}
+
+	// Notify old area (User leaving)
+	s.SendToAreaButUser(&outgoing.CharacterRemovePacket{CharIndex: char.CharIndex}, char.Position, char)
+
+	s.mapService.PutCharacterAtPos(char, dest)
+
+	conn := s.userService.GetConnection(char)
+	if conn != nil {
+		version := int16(0)
+		if m := s.mapService.GetMap(dest.Map); m != nil {
+			version = m.Version
+		}
+		conn.Send(&outgoing.ChangeMapPacket{MapId: dest.Map, Version: version})
+		conn.Send(&outgoing.CharacterCreatePacket{Character: char})
+		conn.Send(&outgoing.UserCharIndexInServerPacket{UserIndex: char.CharIndex})
+		conn.Send(&outgoing.AreaChangedPacket{Position: dest})
+		conn.Send(&outgoing.PosUpdatePacket{X: dest.X, Y: dest.Y})
+
+		// Sync new area state to user (NPCs, Objects, Users)
+		s.areaService.SendAreaState(char)
+	}
+
+	// Notify new area (User entering)
+	s.SendToAreaButUser(&outgoing.CharacterCreatePacket{Character: char}, dest, char)
+	if char.AdminInvisible {
+		s.SendToArea(&outgoing.SetInvisiblePacket{CharIndex: char.CharIndex, Invisible: true}, dest)
+	}
+}
+
 func (s *MessageServiceImpl) SendMessage(char *model.Character, msg string, msgType outgoing.Font) {
 	s.SendConsoleMessage(char, msg, msgType)
 }
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ao-go-server/internal/model"
+	"github.com/ao-go-server/internal/persistence"
+	"github.com/ao-go-server/internal/protocol/outgoing"
+)
+
+// MaxMotdLines caps how many lines the message of the day may have.
+const MaxMotdLines = 20
+
+type MotdServiceImpl struct {
+	dao            persistence.MotdRepository
+	userService    UserService
+	messageService MessageService
+	lines          []string
+	mu             sync.RWMutex
+}
+
+func NewMotdServiceImpl(dao persistence.MotdRepository, userService UserService, messageService MessageService) MotdService {
+	return &MotdServiceImpl{
+		dao:            dao,
+		userService:    userService,
+		messageService: messageService,
+	}
+}
+
+func (s *MotdServiceImpl) LoadMotd() error {
+	lines, err := s.dao.Load()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.lines = lines
+	s.mu.Unlock()
+
+	slog.Info("Successfully loaded MOTD", "lines", len(lines))
+	return nil
+}
+
+func (s *MotdServiceImpl) GetMotd() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]string(nil), s.lines...)
+}
+
+// SetMotd replaces the message of the day with the given text, one line per newline.
+func (s *MotdServiceImpl) SetMotd(text string) error {
+	text = strings.TrimRight(strings.ReplaceAll(text, "\r", ""), "\n")
+
+	var lines []string
+	if text != "" {
+		lines = strings.Split(text, "\n")
+	}
+	if len(lines) > MaxMotdLines {
+		lines = lines[:MaxMotdLines]
+	}
+
+	if err := s.dao.Save(lines); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.lines = lines
+	s.mu.Unlock()
+	return nil
+}
+
+// SendMotd shows the message of the day to the character, filling in the placeholders:
+// {name}, {online}, {time} and {date}.
+func (s *MotdServiceImpl) SendMotd(char *model.Character) {
+	lines := s.GetMotd()
+	if len(lines) == 0 {
+		return
+	}
+
+	now := time.Now()
+	replacer := strings.NewReplacer(
+		"{name}", char.Name,
+		"{online}", strconv.Itoa(len(s.userService.GetLoggedCharacters())),
+		"{time}", now.Format("15:04"),
+		"{date}", now.Format("02/01/2006"),
+	)
+
+	for _, line := range lines {
+		s.messageService.SendConsoleMessage(char, replacer.Replace(line), outgoing.GUILDMSG)
+	}
+}
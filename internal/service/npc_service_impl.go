@@ -2,7 +2,9 @@ package service
 
 import (
 	"log/slog"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/ao-go-server/internal/model"
 	"github.com/ao-go-server/internal/persistence"
@@ -10,19 +12,24 @@ import (
 )
 
 type NpcServiceImpl struct {
-	dao          persistence.NpcRepository
-	npcDefs      map[int]*model.NPC
-	worldNpcs    map[int16]*model.WorldNPC
-	indexManager *CharacterIndexManager
-	mu           sync.RWMutex
+	dao           persistence.NpcRepository
+	npcDefs       map[int]*model.NPC
+	worldNpcs     map[int16]*model.WorldNPC
+	pets          map[int][]*model.WorldNPC // By owner CharIndex
+	respawns      []*model.PendingRespawn
+	indexManager  *CharacterIndexManager
+	globalBalance *model.GlobalBalanceConfig
+	mu            sync.RWMutex
 }
 
-func NewNpcServiceImpl(dao persistence.NpcRepository, indexManager *CharacterIndexManager) NpcService {
+func NewNpcServiceImpl(dao persistence.NpcRepository, indexManager *CharacterIndexManager, globalBalance *model.GlobalBalanceConfig) NpcService {
 	return &NpcServiceImpl{
-		dao:          dao,
-		npcDefs:      make(map[int]*model.NPC),
-		worldNpcs:    make(map[int16]*model.WorldNPC),
-		indexManager: indexManager,
+		dao:           dao,
+		npcDefs:       make(map[int]*model.NPC),
+		worldNpcs:     make(map[int16]*model.WorldNPC),
+		pets:          make(map[int][]*model.WorldNPC),
+		indexManager:  indexManager,
+		globalBalance: globalBalance,
 	}
 }
 
@@ -40,6 +47,16 @@ func (s *NpcServiceImpl) GetNpcDef(id int) *model.NPC {
 	return s.npcDefs[id]
 }
 
+// GetSpawnList returns the NPC definitions GMs can summon, ordered by ID.
+func (s *NpcServiceImpl) GetSpawnList() []*model.NPC {
+	list := make([]*model.NPC, 0, len(s.npcDefs))
+	for _, def := range s.npcDefs {
+		list = append(list, def)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
 func (s *NpcServiceImpl) SpawnNpc(id int, pos model.Position) *model.WorldNPC {
 	def := s.GetNpcDef(id)
 	if def == nil {
@@ -54,6 +71,11 @@ func (s *NpcServiceImpl) SpawnNpc(id int, pos model.Position) *model.WorldNPC {
 		RemainingExp: def.Exp,
 		Index:        s.indexManager.AssignIndex(),
 		Respawn:      def.Respawn,
+		Origin:       pos,
+		LastRestock:  time.Now(),
+	}
+	if def.CanTrade {
+		worldNpc.Inventory = append([]model.InventorySlot(nil), def.Inventory...)
 	}
 
 	s.mu.Lock()
@@ -65,6 +87,7 @@ func (s *NpcServiceImpl) SpawnNpc(id int, pos model.Position) *model.WorldNPC {
 func (s *NpcServiceImpl) RemoveNPC(npc *model.WorldNPC, mapService MapService) {
 	s.mu.Lock()
 	delete(s.worldNpcs, npc.Index)
+	s.removePetLocked(npc)
 	s.mu.Unlock()
 
 	s.indexManager.FreeIndex(npc.Index)
@@ -72,10 +95,99 @@ func (s *NpcServiceImpl) RemoveNPC(npc *model.WorldNPC, mapService MapService) {
 	// Remove from map
 	mapService.RemoveNPC(npc)
 
-	// Respawn logic
 	if npc.Respawn {
-		mapService.SpawnNpcInMap(npc.NPC.ID, npc.Position.Map)
+		s.scheduleRespawn(npc)
+	}
+}
+
+func (s *NpcServiceImpl) scheduleRespawn(npc *model.WorldNPC) {
+	delay := npc.NPC.RespawnTime
+	if npc.SpawnRegion != nil {
+		if entry := npc.SpawnRegion.GetNpc(npc.NPC.ID); entry != nil && entry.RespawnTime > 0 {
+			delay = entry.RespawnTime
+		}
+	}
+	if delay <= 0 {
+		delay = s.globalBalance.NPCRespawnTime
+	}
+
+	pending := &model.PendingRespawn{
+		NpcID:  npc.NPC.ID,
+		Map:    npc.Position.Map,
+		Region: npc.SpawnRegion,
+		DueAt:  time.Now().Add(time.Duration(delay) * time.Second),
+	}
+	if npc.NPC.FixedOrigin && npc.SpawnRegion == nil {
+		origin := npc.Origin
+		pending.Origin = &origin
+	}
+
+	s.mu.Lock()
+	s.respawns = append(s.respawns, pending)
+	s.mu.Unlock()
+}
+
+func (s *NpcServiceImpl) ProcessRespawns(mapService MapService, areaService AreaService) {
+	now := time.Now()
+
+	s.mu.Lock()
+	var due []*model.PendingRespawn
+	waiting := s.respawns[:0]
+	for _, r := range s.respawns {
+		if now.Before(r.DueAt) {
+			waiting = append(waiting, r)
+		} else {
+			due = append(due, r)
+		}
+	}
+	s.respawns = waiting
+	s.mu.Unlock()
+
+	for _, r := range due {
+		var npc *model.WorldNPC
+		switch {
+		case r.Region != nil:
+			npc = mapService.SpawnNpcInRegion(r.NpcID, r.Region)
+		case r.Origin != nil:
+			npc = mapService.SpawnNpcAt(r.NpcID, *r.Origin)
+			if npc != nil {
+				// It may have landed next to its origin if someone was standing there
+				npc.Origin = *r.Origin
+			}
+		default:
+			npc = mapService.SpawnNpcInMap(r.NpcID, r.Map)
+		}
+
+		if npc == nil {
+			slog.Debug("NPC respawn skipped", "npc", r.NpcID, "map", r.Map)
+			continue
+		}
+		areaService.BroadcastToArea(npc.Position, &outgoing.NpcCreatePacket{Npc: npc})
+	}
+}
+
+func (s *NpcServiceImpl) GetPendingRespawns() []model.PendingRespawn {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]model.PendingRespawn, 0, len(s.respawns))
+	for _, r := range s.respawns {
+		list = append(list, *r)
+	}
+	return list
+}
+
+func (s *NpcServiceImpl) CancelRespawns(mapID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	waiting := s.respawns[:0]
+	for _, r := range s.respawns {
+		if r.Map != mapID {
+			waiting = append(waiting, r)
+		}
 	}
+	s.respawns = waiting
 }
 
 func (s *NpcServiceImpl) GetWorldNpcs() []*model.WorldNPC {
@@ -95,6 +207,43 @@ func (s *NpcServiceImpl) GetWorldNpcByIndex(index int16) *model.WorldNPC {
 	return s.worldNpcs[index]
 }
 
+// SetOwner makes the NPC a pet of the user with the given CharIndex, 0 sets it free.
+func (s *NpcServiceImpl) SetOwner(npc *model.WorldNPC, ownerIndex int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removePetLocked(npc)
+	npc.OwnerIndex = ownerIndex
+	if ownerIndex != 0 {
+		s.pets[ownerIndex] = append(s.pets[ownerIndex], npc)
+	}
+}
+
+// GetPets returns the NPCs owned by the user with the given CharIndex.
+func (s *NpcServiceImpl) GetPets(ownerIndex int) []*model.WorldNPC {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]*model.WorldNPC(nil), s.pets[ownerIndex]...)
+}
+
+func (s *NpcServiceImpl) removePetLocked(npc *model.WorldNPC) {
+	if npc.OwnerIndex == 0 {
+		return
+	}
+	pets := s.pets[npc.OwnerIndex]
+	for i, pet := range pets {
+		if pet == npc {
+			pets = append(pets[:i], pets[i+1:]...)
+			break
+		}
+	}
+	if len(pets) == 0 {
+		delete(s.pets, npc.OwnerIndex)
+	} else {
+		s.pets[npc.OwnerIndex] = pets
+	}
+}
+
 func (s *NpcServiceImpl) ChangeNpcHeading(npc *model.WorldNPC, heading model.Heading, areaService AreaService) {
 	npc.Heading = heading
 	areaService.BroadcastToArea(npc.Position, &outgoing.NpcChangePacket{Npc: npc})
@@ -33,3 +33,17 @@ func (s *ObjectServiceImpl) LoadObjects() error {
 func (s *ObjectServiceImpl) GetObject(id int) *model.Object {
 	return s.objects[id]
 }
+
+// GetEquipped returns the object of the given type the character has equipped, if any.
+func (s *ObjectServiceImpl) GetEquipped(char *model.Character, objType model.ObjectType) *model.Object {
+	for i := 0; i < model.InventorySlots; i++ {
+		slot := char.Inventory.GetSlot(i)
+		if slot.Equipped {
+			obj := s.GetObject(slot.ObjectID)
+			if obj != nil && obj.Type == objType {
+				return obj
+			}
+		}
+	}
+	return nil
+}
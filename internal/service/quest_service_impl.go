@@ -0,0 +1,316 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/ao-go-server/internal/model"
+	"github.com/ao-go-server/internal/persistence"
+	"github.com/ao-go-server/internal/protocol/outgoing"
+)
+
+// QuestReachRadius is how close (in tiles) a character must get to a reach objective's position.
+const QuestReachRadius = 2
+
+type QuestServiceImpl struct {
+	dao             persistence.QuestRepository
+	userService     UserService
+	npcService      NpcService
+	messageService  MessageService
+	objectService   ObjectService
+	trainingService TrainingService
+	quests          map[int]*model.Quest
+}
+
+func NewQuestServiceImpl(dao persistence.QuestRepository, userService UserService, npcService NpcService, messageService MessageService, objectService ObjectService, trainingService TrainingService) QuestService {
+	return &QuestServiceImpl{
+		dao:             dao,
+		userService:     userService,
+		npcService:      npcService,
+		messageService:  messageService,
+		objectService:   objectService,
+		trainingService: trainingService,
+		quests:          make(map[int]*model.Quest),
+	}
+}
+
+func (s *QuestServiceImpl) LoadQuests() error {
+	defs, err := s.dao.Load()
+	if err != nil {
+		return err
+	}
+	s.quests = defs
+	slog.Info("Successfully loaded quests", "count", len(s.quests))
+	return nil
+}
+
+func (s *QuestServiceImpl) GetQuest(id int) *model.Quest {
+	return s.quests[id]
+}
+
+func (s *QuestServiceImpl) TalkToNpc(char *model.Character, npc *model.WorldNPC) bool {
+	// Talk objectives are fulfilled by any interaction, even with NPCs that don't give quests
+	s.forEachObjective(char, func(quest *model.Quest, progress *model.QuestProgress, idx int, obj model.QuestObjective) {
+		if obj.Type == model.QuestTalkToNpc && obj.TargetID == npc.NPC.ID && progress.Progress[idx] < 1 {
+			progress.Progress[idx] = 1
+			s.messageService.SendConsoleMessage(char, fmt.Sprintf("Misión '%s': has hablado con %s.", quest.Name, npc.NPC.Name), outgoing.INFO)
+		}
+	})
+
+	offered := s.questsGivenBy(npc.NPC.ID)
+	if len(offered) == 0 {
+		return false
+	}
+
+	if char.Dead {
+		s.messageService.SendConsoleMessage(char, "¡Estás muerto!", outgoing.INFO)
+		return true
+	}
+
+	if char.Position.GetDistance(npc.Position) > 3 {
+		s.messageService.SendConsoleMessage(char, "Estás demasiado lejos.", outgoing.INFO)
+		return true
+	}
+
+	// Turn in finished quests first, otherwise remind the player what is left
+	var pending *model.QuestProgress
+	for _, quest := range offered {
+		progress := char.GetQuestProgress(quest.ID)
+		if progress == nil {
+			continue
+		}
+		if s.isComplete(char, quest, progress) {
+			s.completeQuest(char, quest, progress)
+			return true
+		}
+		if pending == nil {
+			pending = progress
+		}
+	}
+
+	if pending != nil {
+		s.sendQuestStatus(char, s.quests[pending.QuestID], pending)
+		return true
+	}
+
+	for _, quest := range offered {
+		if char.HasCompletedQuest(quest.ID) || int(char.Level) < quest.MinLevel {
+			continue
+		}
+
+		if len(char.Quests) >= model.MaxActiveQuests {
+			s.messageService.SendConsoleMessage(char, fmt.Sprintf("No puedes tener más de %d misiones activas.", model.MaxActiveQuests), outgoing.INFO)
+			return true
+		}
+
+		progress := &model.QuestProgress{QuestID: quest.ID, Progress: make([]int, len(quest.Objectives))}
+		char.Quests = append(char.Quests, progress)
+
+		s.messageService.SendConsoleMessage(char, fmt.Sprintf("Has aceptado la misión: %s", quest.Name), outgoing.INFOBOLD)
+		s.sendQuestStatus(char, quest, progress)
+
+		// The player might already be standing on a reach objective
+		s.OnCharacterMoved(char)
+		return true
+	}
+
+	s.messageService.SendConsoleMessage(char, fmt.Sprintf("%s no tiene tareas para ti por ahora.", npc.NPC.Name), outgoing.INFO)
+	return true
+}
+
+func (s *QuestServiceImpl) OnNpcKilled(char *model.Character, npc *model.WorldNPC) {
+	s.forEachObjective(char, func(quest *model.Quest, progress *model.QuestProgress, idx int, obj model.QuestObjective) {
+		if obj.Type == model.QuestKillNpc && obj.TargetID == npc.NPC.ID && progress.Progress[idx] < obj.Amount {
+			progress.Progress[idx]++
+			s.messageService.SendConsoleMessage(char, fmt.Sprintf("Misión '%s': %s %d/%d.", quest.Name, npc.NPC.Name, progress.Progress[idx], obj.Amount), outgoing.INFO)
+		}
+	})
+}
+
+func (s *QuestServiceImpl) OnCharacterMoved(char *model.Character) {
+	s.forEachObjective(char, func(quest *model.Quest, progress *model.QuestProgress, idx int, obj model.QuestObjective) {
+		if obj.Type != model.QuestReachPosition || progress.Progress[idx] >= 1 {
+			return
+		}
+		if char.Position.Map == obj.Position.Map && char.Position.GetDistance(obj.Position) <= QuestReachRadius {
+			progress.Progress[idx] = 1
+			s.messageService.SendConsoleMessage(char, fmt.Sprintf("Misión '%s': has llegado a destino.", quest.Name), outgoing.INFO)
+		}
+	})
+}
+
+// forEachObjective walks every objective of the character's active quests. Progress slices
+// are resized first so quest definitions can change without breaking saved characters.
+func (s *QuestServiceImpl) forEachObjective(char *model.Character, f func(quest *model.Quest, progress *model.QuestProgress, idx int, obj model.QuestObjective)) {
+	for _, progress := range char.Quests {
+		quest := s.quests[progress.QuestID]
+		if quest == nil {
+			continue
+		}
+
+		for len(progress.Progress) < len(quest.Objectives) {
+			progress.Progress = append(progress.Progress, 0)
+		}
+		progress.Progress = progress.Progress[:len(quest.Objectives)]
+
+		for idx, obj := range quest.Objectives {
+			f(quest, progress, idx, obj)
+		}
+	}
+}
+
+func (s *QuestServiceImpl) questsGivenBy(npcID int) []*model.Quest {
+	var result []*model.Quest
+	for _, quest := range s.quests {
+		if quest.GiverNPC == npcID {
+			result = append(result, quest)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+func (s *QuestServiceImpl) objectiveDone(char *model.Character, progress *model.QuestProgress, idx int, obj model.QuestObjective) bool {
+	if obj.Type == model.QuestCollectObject {
+		return char.Inventory.CountUnequippedItem(obj.TargetID) >= obj.Amount
+	}
+	return idx < len(progress.Progress) && progress.Progress[idx] >= obj.Amount
+}
+
+func (s *QuestServiceImpl) isComplete(char *model.Character, quest *model.Quest, progress *model.QuestProgress) bool {
+	for idx, obj := range quest.Objectives {
+		if !s.objectiveDone(char, progress, idx, obj) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *QuestServiceImpl) sendQuestStatus(char *model.Character, quest *model.Quest, progress *model.QuestProgress) {
+	if quest.Description != "" {
+		s.messageService.SendConsoleMessage(char, quest.Description, outgoing.INFO)
+	}
+
+	for idx, obj := range quest.Objectives {
+		line := ""
+		switch obj.Type {
+		case model.QuestKillNpc:
+			name := "criaturas"
+			if def := s.npcService.GetNpcDef(obj.TargetID); def != nil {
+				name = def.Name
+			}
+			line = fmt.Sprintf("Matar %s: %d/%d", name, progress.Progress[idx], obj.Amount)
+		case model.QuestCollectObject:
+			name := "objetos"
+			if def := s.objectService.GetObject(obj.TargetID); def != nil {
+				name = def.Name
+			}
+			line = fmt.Sprintf("Juntar %s: %d/%d", name, min(char.Inventory.CountUnequippedItem(obj.TargetID), obj.Amount), obj.Amount)
+		case model.QuestReachPosition:
+			line = fmt.Sprintf("Llegar a Mapa %d (%d, %d)", obj.Position.Map, obj.Position.X, obj.Position.Y)
+		case model.QuestTalkToNpc:
+			name := "alguien"
+			if def := s.npcService.GetNpcDef(obj.TargetID); def != nil {
+				name = def.Name
+			}
+			line = fmt.Sprintf("Hablar con %s", name)
+		}
+
+		if s.objectiveDone(char, progress, idx, obj) {
+			line += " (completado)"
+		}
+		s.messageService.SendConsoleMessage(char, "- "+line, outgoing.INFO)
+	}
+}
+
+func (s *QuestServiceImpl) completeQuest(char *model.Character, quest *model.Quest, progress *model.QuestProgress) {
+	// Make sure every reward item fits before touching the inventory
+	needed := 0
+	for _, reward := range quest.RewardItems {
+		if char.Inventory.CountItem(reward.ObjectID) == 0 {
+			needed++
+		}
+	}
+	free := 0
+	for i := 0; i < model.InventorySlots; i++ {
+		if char.Inventory.Slots[i].ObjectID == 0 {
+			free++
+		}
+	}
+	if free < needed {
+		s.messageService.SendConsoleMessage(char, "No tienes espacio suficiente en el inventario para recibir la recompensa.", outgoing.INFO)
+		return
+	}
+
+	for _, obj := range quest.Objectives {
+		if obj.Type == model.QuestCollectObject {
+			s.takeItems(char, obj.TargetID, obj.Amount)
+		}
+	}
+
+	for i, p := range char.Quests {
+		if p == progress {
+			char.Quests = append(char.Quests[:i], char.Quests[i+1:]...)
+			break
+		}
+	}
+	char.CompletedQuests = append(char.CompletedQuests, quest.ID)
+
+	s.messageService.SendConsoleMessage(char, fmt.Sprintf("¡Has completado la misión: %s!", quest.Name), outgoing.INFOBOLD)
+
+	if quest.RewardGold > 0 {
+		char.Gold += quest.RewardGold
+		s.messageService.SendConsoleMessage(char, fmt.Sprintf("Has recibido %d monedas de oro.", quest.RewardGold), outgoing.INFO)
+	}
+
+	for _, reward := range quest.RewardItems {
+		if char.Inventory.AddItem(reward.ObjectID, reward.Amount) {
+			if obj := s.objectService.GetObject(reward.ObjectID); obj != nil {
+				s.messageService.SendConsoleMessage(char, fmt.Sprintf("Has recibido %d %s.", reward.Amount, obj.Name), outgoing.INFO)
+			}
+		}
+	}
+
+	if quest.RewardExp > 0 {
+		char.Exp += quest.RewardExp
+		s.messageService.SendConsoleMessage(char, fmt.Sprintf("Has ganado %d puntos de experiencia.", quest.RewardExp), outgoing.INFO)
+		s.trainingService.CheckLevel(char)
+	}
+
+	slog.Info("Quest completed", "user", char.Name, "quest", quest.ID)
+
+	conn := s.userService.GetConnection(char)
+	if conn == nil {
+		return
+	}
+
+	conn.Send(outgoing.NewUpdateUserStatsPacket(char))
+	for i := 0; i < model.InventorySlots; i++ {
+		slot := char.Inventory.Slots[i]
+		conn.Send(&outgoing.ChangeInventorySlotPacket{
+			Slot:     byte(i + 1),
+			Object:   s.objectService.GetObject(slot.ObjectID),
+			Amount:   slot.Amount,
+			Equipped: slot.Equipped,
+		})
+	}
+}
+
+// takeItems removes collected objects, leaving what the character wears alone. Only unequipped
+// units count towards collect objectives, so there are always enough.
+func (s *QuestServiceImpl) takeItems(char *model.Character, objectID, amount int) {
+	for i := 0; i < model.InventorySlots && amount > 0; i++ {
+		slot := &char.Inventory.Slots[i]
+		if slot.ObjectID != objectID || slot.Equipped {
+			continue
+		}
+
+		taken := min(slot.Amount, amount)
+		slot.Amount -= taken
+		amount -= taken
+		if slot.Amount <= 0 {
+			*slot = model.InventorySlot{}
+		}
+	}
+}
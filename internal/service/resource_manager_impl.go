@@ -11,15 +11,17 @@ type ResourceManagerImpl struct {
 	mapService    MapService
 	spellService  SpellService
 	cityService   CityService
+	questService  QuestService
 }
 
-func NewResourceManagerImpl(objectService ObjectService, npcService NpcService, mapService MapService, spellService SpellService, cityService CityService) ResourceManager {
+func NewResourceManagerImpl(objectService ObjectService, npcService NpcService, mapService MapService, spellService SpellService, cityService CityService, questService QuestService) ResourceManager {
 	return &ResourceManagerImpl{
 		objectService: objectService,
 		npcService:    npcService,
 		mapService:    mapService,
 		spellService:  spellService,
 		cityService:   cityService,
+		questService:  questService,
 	}
 }
 
@@ -40,6 +42,9 @@ func (s *ResourceManagerImpl) LoadAll() {
 	if err := s.spellService.LoadSpells(); err != nil {
 		slog.Error("Error loading spells", "error", err)
 	}
+	if err := s.questService.LoadQuests(); err != nil {
+		slog.Error("Error loading quests", "error", err)
+	}
 
 	// Maps are the slowest and depend on Objects and NPCs
 	s.mapService.LoadMaps()
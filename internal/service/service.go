@@ -1,6 +1,8 @@
 package service
 
 import (
+	"time"
+
 	"github.com/ao-go-server/internal/model"
 	"github.com/ao-go-server/internal/network"
 	"github.com/ao-go-server/internal/protocol"
@@ -29,20 +31,30 @@ type MapService interface {
 	MoveNpc(npc *model.WorldNPC, newPos model.Position) bool
 	MoveCharacterTo(char *model.Character, heading model.Heading) (model.Position, bool)
 	IsSafeZone(pos model.Position) bool
+	IsFightZone(pos model.Position) bool
+	IsAntiPicket(pos model.Position) bool
 	IsPkMap(mapID int) bool
 	IsInvalidPosition(pos model.Position) bool
 	IsTileEmpty(mapID int, x, y int) bool
 	IsBlocked(mapID, x, y int) bool
 	SpawnNpcInMap(npcID int, mapID int) *model.WorldNPC
+	SpawnNpcAt(npcID int, pos model.Position) *model.WorldNPC
+	SpawnNpcInRegion(npcID int, region *model.SpawnRegion) *model.WorldNPC
 }
 
 type NpcService interface {
 	LoadNpcs() error
 	GetNpcDef(id int) *model.NPC
+	GetSpawnList() []*model.NPC
 	SpawnNpc(id int, pos model.Position) *model.WorldNPC
 	RemoveNPC(npc *model.WorldNPC, mapService MapService)
+	ProcessRespawns(mapService MapService, areaService AreaService)
+	GetPendingRespawns() []model.PendingRespawn
+	CancelRespawns(mapID int)
 	GetWorldNpcs() []*model.WorldNPC
 	GetWorldNpcByIndex(index int16) *model.WorldNPC
+	SetOwner(npc *model.WorldNPC, ownerIndex int)
+	GetPets(ownerIndex int) []*model.WorldNPC
 	ChangeNpcHeading(npc *model.WorldNPC, heading model.Heading, areaService AreaService)
 	MoveNpc(npc *model.WorldNPC, newPos model.Position, heading model.Heading, mapService MapService, areaService AreaService) bool
 }
@@ -50,6 +62,7 @@ type NpcService interface {
 type ObjectService interface {
 	LoadObjects() error
 	GetObject(id int) *model.Object
+	GetEquipped(char *model.Character, objType model.ObjectType) *model.Object
 }
 
 type UserService interface {
@@ -92,6 +105,8 @@ type MessageService interface {
 	SendToMap(packet protocol.OutgoingPacket, mapId int)
 	HandleDeath(char *model.Character, msg string)
 	HandleResurrection(char *model.Character)
+	WarpCharacter(char *model.Character, pos model.Position)
+	FindDropPosition(startPos model.Position) *model.Position
 	MapService() MapService
 	UserService() UserService
 	AreaService() AreaService
@@ -99,7 +114,9 @@ type MessageService interface {
 
 type CombatService interface {
 	ResolveAttack(attacker *model.Character, target any)
+	ResolveRangedAttack(attacker *model.Character, target any)
 	NpcAtacaUser(npc *model.WorldNPC, victim *model.Character) bool
+	NpcAtacaNpc(attacker, victim *model.WorldNPC) bool
 }
 
 type SpellService interface {
@@ -109,10 +126,19 @@ type SpellService interface {
 	NpcLanzaSpellSobreUser(npc *model.WorldNPC, target *model.Character, spellID int) bool
 	PriestHealUser(target *model.Character)
 	PriestResucitateUser(target *model.Character)
+	SendSpellInfo(char *model.Character, slot int)
+	MoveSpell(char *model.Character, slot int, upwards bool)
+	ForgetSpell(char *model.Character, slot int)
+	ProcessFields(now time.Time)
+}
+
+type LootService interface {
+	DropNpcLoot(killer *model.Character, npc *model.WorldNPC)
 }
 
 type SkillService interface {
 	HandleUseSkillClick(user *model.Character, skill model.Skill, x, y byte)
+	Hide(user *model.Character)
 }
 
 type LoginService interface {
@@ -120,7 +146,13 @@ type LoginService interface {
 	ConnectExistingCharacter(conn protocol.Connection, nick, password, version, clientHash string) error
 	OnUserDisconnect(conn protocol.Connection)
 	LockAccount(nick string) error
+	PrivilegesOf(nick string) model.PrivilegeLevel
 	UnlockAccount(nick string) error
+	LoadBannedIPs() error
+	BanIP(ip string) error
+	UnbanIP(ip string) (bool, error)
+	IsIPBanned(ip string) bool
+	GetBannedIPs() []string
 	ResetPassword(nick, newPassword string) error
 	TeleportPlayer(nick string, mapID, x, y int) error
 	SavePlayer(nick string) error
@@ -150,6 +182,18 @@ type IntervalService interface {
 
 type TrainingService interface {
 	CheckLevel(char *model.Character)
+	ImproveSkill(char *model.Character, skill model.Skill)
+}
+
+type EffectService interface {
+	// Apply puts an effect on a character for duration (zero lasts until dispelled), stacking
+	// with the effect of the same type already running.
+	Apply(char *model.Character, effectType model.EffectType, magnitude int, duration time.Duration)
+	// Dispel removes an effect and tells whether it was there.
+	Dispel(char *model.Character, effectType model.EffectType) bool
+	DispelAll(char *model.Character)
+	// Tick hurts poisoned characters and expires finished effects, telling whether stats changed.
+	Tick(char *model.Character, now time.Time) bool
 }
 
 type ItemActionService interface {
@@ -171,6 +215,51 @@ type BankService interface {
 	ExtractItem(char *model.Character, bankSlotIdx int, amount int)
 }
 
+type CommerceService interface {
+	OpenCommerce(char *model.Character, npc *model.WorldNPC)
+	CloseCommerce(char *model.Character)
+	BuyItem(char *model.Character, npcSlotIdx int, amount int)
+	SellItem(char *model.Character, slotIdx int, amount int)
+	RestockMerchants()
+}
+
+type QuestService interface {
+	LoadQuests() error
+	GetQuest(id int) *model.Quest
+	TalkToNpc(char *model.Character, npc *model.WorldNPC) bool
+	OnNpcKilled(char *model.Character, npc *model.WorldNPC)
+	OnCharacterMoved(char *model.Character)
+}
+
+type GamblingService interface {
+	Bet(char *model.Character, amount int)
+}
+
+type DuelService interface {
+	Challenge(challenger *model.Character, targetName string)
+	Accept(char *model.Character)
+	Decline(char *model.Character)
+	IsConsensualFight(attacker, victim *model.Character) bool
+	HandleDefeat(winner, loser *model.Character) bool
+	Forfeit(char *model.Character)
+	ProcessCountdowns(now time.Time)
+}
+
+type MotdService interface {
+	LoadMotd() error
+	GetMotd() []string
+	SetMotd(text string) error
+	SendMotd(char *model.Character)
+}
+
+type SosService interface {
+	LoadTickets() error
+	Submit(char *model.Character, message string)
+	GetOpenTickets() []*model.SosTicket
+	Claim(gm *model.Character, ref string)
+	Close(closedBy string, ref string) bool
+}
+
 type GmService interface {
 	HandleCommand(conn protocol.Connection, cmdID byte, buffer *network.DataBuffer) (bool, error)
 }
@@ -22,13 +22,17 @@ type SkillServiceImpl struct {
 
 	spellService   SpellService
 
+	combatService  CombatService
+
 	intervals      IntervalService
 
+	trainingService TrainingService
+
 }
 
 
 
-func NewSkillServiceImpl(mapService MapService, objectService ObjectService, messageService MessageService, userService UserService, npcService NpcService, spellService SpellService, intervals IntervalService) SkillService {
+func NewSkillServiceImpl(mapService MapService, objectService ObjectService, messageService MessageService, userService UserService, npcService NpcService, spellService SpellService, combatService CombatService, intervals IntervalService, trainingService TrainingService) SkillService {
 
 	return &SkillServiceImpl{
 
@@ -44,8 +48,12 @@ func NewSkillServiceImpl(mapService MapService, objectService ObjectService, mes
 
 		spellService:	spellService,
 
+		combatService:	combatService,
+
 		intervals:		intervals,
 
+		trainingService:	trainingService,
+
 	}
 
 }
@@ -70,7 +78,7 @@ func (s *SkillServiceImpl) HandleUseSkillClick(user *model.Character, skill mode
 
 	// Check intervals for specific working skills (Mining, Fishing, Lumber, Stealing, Taming)
 
-	if skill != model.Magic {
+	if skill != model.Magic && skill != model.Projectiles {
 
 		if !s.intervals.CanWork(user) {
 
@@ -118,6 +126,12 @@ func (s *SkillServiceImpl) HandleUseSkillClick(user *model.Character, skill mode
 
 
 
+	case model.Projectiles:
+
+		s.handleProjectiles(user, x, y)
+
+
+
 	case model.Fishing:
 
 		s.handleFishing(user, x, y)
@@ -178,6 +192,25 @@ func (s *SkillServiceImpl) HandleUseSkillClick(user *model.Character, skill mode
 
 }
 
+// Hide tries to conceal the user in the shadows; better hiders succeed more often.
+func (s *SkillServiceImpl) Hide(user *model.Character) {
+	if user.Dead || user.Hidden || !s.intervals.CanWork(user) {
+		return
+	}
+	s.intervals.UpdateLastWork(user)
+
+	chance := 20 + user.Skills[model.Hiding]*3/4
+	if rand.Intn(100) >= chance {
+		s.messageService.SendConsoleMessage(user, "¡No has logrado esconderte!", outgoing.INFO)
+		return
+	}
+
+	user.Hidden = true
+	s.messageService.SendToArea(&outgoing.SetInvisiblePacket{CharIndex: user.CharIndex, Invisible: true}, user.Position)
+	s.messageService.SendConsoleMessage(user, "¡Te has escondido entre las sombras!", outgoing.INFO)
+	s.trainingService.ImproveSkill(user, model.Hiding)
+}
+
 func (s *SkillServiceImpl) handleMagic(user *model.Character, x, y byte) {
 	slog.Debug("handleMagic", "user", user.Name, "spell", user.SelectedSpell, "x", x, "y", y)
 	if user.SelectedSpell == 0 {
@@ -190,29 +223,13 @@ func (s *SkillServiceImpl) handleMagic(user *model.Character, x, y byte) {
 		return
 	}
 
-	// 2-tile hitbox logic: check Y+1 first (head overlap), then Y.
-	var target any
-	
-	// Check Y+1
-	if int(y)+1 < model.MapHeight {
-		tile := m.GetTile(int(x), int(y)+1)
-		if tile.Character != nil {
-			target = tile.Character
-		} else if tile.NPC != nil {
-			target = tile.NPC
-		}
-	}
-
-	// If not found, check clicked tile Y
-	if target == nil {
-		tile := m.GetTile(int(x), int(y))
-		if tile.Character != nil {
-			target = tile.Character
-		} else if tile.NPC != nil {
-			target = tile.NPC
-		}
+	// Terrain spells aim at the tile itself, whoever stands on it
+	if spell := s.spellService.GetSpell(user.SelectedSpell); spell != nil && spell.TargetType == model.TargetTerrain {
+		s.spellService.CastSpell(user, user.SelectedSpell, model.Position{Map: user.Position.Map, X: x, Y: y})
+		return
 	}
 
+	target := s.targetAt(m, x, y)
 	if target != nil {
 		s.spellService.CastSpell(user, user.SelectedSpell, target)
 	} else {
@@ -221,6 +238,38 @@ func (s *SkillServiceImpl) handleMagic(user *model.Character, x, y byte) {
 	}
 }
 
+// targetAt returns the character or NPC under a click. Characters are two tiles tall, so the tile
+// below the click is checked first (head overlap) and then the clicked tile itself.
+func (s *SkillServiceImpl) targetAt(m *model.Map, x, y byte) any {
+	tiles := []int{int(y) + 1, int(y)}
+	for _, ty := range tiles {
+		if ty >= model.MapHeight {
+			continue
+		}
+		tile := m.GetTile(int(x), ty)
+		if tile.Character != nil {
+			return tile.Character
+		} else if tile.NPC != nil {
+			return tile.NPC
+		}
+	}
+	return nil
+}
+
+func (s *SkillServiceImpl) handleProjectiles(user *model.Character, x, y byte) {
+	m := s.mapService.GetMap(user.Position.Map)
+	if m == nil {
+		return
+	}
+
+	target := s.targetAt(m, x, y)
+	if target == nil {
+		s.messageService.SendConsoleMessage(user, "No hay nadie a quien atacar.", outgoing.INFO)
+		return
+	}
+	s.combatService.ResolveRangedAttack(user, target)
+}
+
 func (s *SkillServiceImpl) handleFishing(user *model.Character, x, y byte) {
 	// 1. Check Tool
 	// 2. Check Water
@@ -282,6 +331,7 @@ func (s *SkillServiceImpl) handleTaming(user *model.Character, x, y byte) {
 
 	if success {
 		s.messageService.SendConsoleMessage(user, "¡Has domado la criatura!", outgoing.INFO)
+		s.trainingService.ImproveSkill(user, model.Tame)
 		// TODO: Convert NPC to Pet
 	} else {
 		s.messageService.SendConsoleMessage(user, "Has fallado en el intento.", outgoing.INFO)
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/ao-go-server/internal/model"
+	"github.com/ao-go-server/internal/persistence"
+	"github.com/ao-go-server/internal/protocol/outgoing"
+)
+
+type SosServiceImpl struct {
+	dao            persistence.SosRepository
+	userService    UserService
+	messageService MessageService
+	tickets        []*model.SosTicket
+	nextID         int
+	mu             sync.Mutex
+}
+
+func NewSosServiceImpl(dao persistence.SosRepository, userService UserService, messageService MessageService) SosService {
+	return &SosServiceImpl{
+		dao:            dao,
+		userService:    userService,
+		messageService: messageService,
+		nextID:         1,
+	}
+}
+
+func (s *SosServiceImpl) LoadTickets() error {
+	tickets, nextID, err := s.dao.Load()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.tickets = tickets
+	s.nextID = nextID
+	s.mu.Unlock()
+
+	slog.Info("Successfully loaded SOS tickets", "count", len(tickets))
+	return nil
+}
+
+func (s *SosServiceImpl) Submit(char *model.Character, message string) {
+	// Control characters would break the ticket file and the GM listings, and runs of
+	// whitespace are squeezed so the message stays on one line
+	message = strings.Join(strings.Fields(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, message)), " ")
+	if message == "" {
+		s.messageService.SendConsoleMessage(char, "Escribe el motivo de tu consulta.", outgoing.INFO)
+		return
+	}
+	if runes := []rune(message); len(runes) > model.MaxSosMessageLength {
+		message = string(runes[:model.MaxSosMessageLength])
+	}
+
+	s.mu.Lock()
+	ticket := s.findByAuthor(char.Name)
+	if ticket != nil {
+		// A player keeps a single ticket, asking again updates it
+		ticket.Message = message
+		ticket.Position = char.Position
+	} else {
+		ticket = &model.SosTicket{
+			ID:        s.nextID,
+			Author:    char.Name,
+			Message:   message,
+			Position:  char.Position,
+			CreatedAt: time.Now(),
+		}
+		s.nextID++
+		s.tickets = append(s.tickets, ticket)
+	}
+	s.saveLocked()
+	s.mu.Unlock()
+
+	s.messageService.SendConsoleMessage(char, "Tu consulta fue enviada a los GMs, aguarda a ser atendido.", outgoing.INFO)
+	s.notifyGMs(fmt.Sprintf("SOS #%d de %s: %s", ticket.ID, char.Name, message))
+}
+
+func (s *SosServiceImpl) GetOpenTickets() []*model.SosTicket {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]*model.SosTicket, len(s.tickets))
+	copy(list, s.tickets)
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+func (s *SosServiceImpl) Claim(gm *model.Character, ref string) {
+	s.mu.Lock()
+	ticket := s.find(ref)
+	if ticket == nil {
+		s.mu.Unlock()
+		s.messageService.SendConsoleMessage(gm, "No existe esa consulta.", outgoing.INFO)
+		return
+	}
+	if ticket.ClaimedBy != "" && !strings.EqualFold(ticket.ClaimedBy, gm.Name) {
+		s.mu.Unlock()
+		s.messageService.SendConsoleMessage(gm, fmt.Sprintf("La consulta #%d ya la atiende %s.", ticket.ID, ticket.ClaimedBy), outgoing.INFO)
+		return
+	}
+	ticket.ClaimedBy = gm.Name
+	s.saveLocked()
+	s.mu.Unlock()
+
+	s.messageService.SendConsoleMessage(gm, fmt.Sprintf("Atiendes la consulta #%d de %s: %s", ticket.ID, ticket.Author, ticket.Message), outgoing.INFO)
+	if author := s.userService.GetCharacterByName(ticket.Author); author != nil {
+		s.messageService.SendConsoleMessage(author, fmt.Sprintf("%s está atendiendo tu consulta.", gm.Name), outgoing.INFO)
+	}
+}
+
+func (s *SosServiceImpl) Close(closedBy string, ref string) bool {
+	s.mu.Lock()
+	ticket := s.find(ref)
+	if ticket == nil {
+		s.mu.Unlock()
+		return false
+	}
+	for i, t := range s.tickets {
+		if t == ticket {
+			s.tickets = append(s.tickets[:i], s.tickets[i+1:]...)
+			break
+		}
+	}
+	s.saveLocked()
+	s.mu.Unlock()
+
+	slog.Info("SOS ticket closed", "id", ticket.ID, "author", ticket.Author, "by", closedBy)
+	if author := s.userService.GetCharacterByName(ticket.Author); author != nil {
+		s.messageService.SendConsoleMessage(author, "Tu consulta fue cerrada.", outgoing.INFO)
+	}
+	return true
+}
+
+// find looks a ticket up by its ID or by the author's name. A reference starting with #
+// is read as an ID up to the first space, so an entry of the SOS form can be passed as is.
+func (s *SosServiceImpl) find(ref string) *model.SosTicket {
+	ref = strings.TrimSpace(ref)
+	if strings.HasPrefix(ref, "#") {
+		ref = strings.Fields(ref[1:] + " ")[0]
+	}
+	if id, err := strconv.Atoi(ref); err == nil {
+		for _, t := range s.tickets {
+			if t.ID == id {
+				return t
+			}
+		}
+		return nil
+	}
+	return s.findByAuthor(ref)
+}
+
+func (s *SosServiceImpl) findByAuthor(name string) *model.SosTicket {
+	for _, t := range s.tickets {
+		if strings.EqualFold(t.Author, name) {
+			return t
+		}
+	}
+	return nil
+}
+
+func (s *SosServiceImpl) saveLocked() {
+	if err := s.dao.Save(s.tickets, s.nextID); err != nil {
+		slog.Error("Failed to save SOS tickets", "error", err)
+	}
+}
+
+func (s *SosServiceImpl) notifyGMs(msg string) {
+	for _, char := range s.userService.GetLoggedCharacters() {
+		if char.Privileges.IsGM() {
+			s.messageService.SendConsoleMessage(char, msg, outgoing.GMMSG)
+		}
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"log/slog"
 	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/ao-go-server/internal/config"
 	"github.com/ao-go-server/internal/model"
 	"github.com/ao-go-server/internal/persistence"
 	"github.com/ao-go-server/internal/protocol/outgoing"
+	"github.com/ao-go-server/internal/utils"
 )
 
 type SpellServiceImpl struct {
@@ -21,11 +23,27 @@ type SpellServiceImpl struct {
 	intervals       IntervalService
 	trainingService TrainingService
 	areaService     AreaService
+	questService    QuestService
+	duelService     DuelService
+	lootService     LootService
+	effectService   EffectService
 	spells          map[int]*model.Spell
+	fields          []*spellField
+	fieldsMu        sync.Mutex
 	config          *config.Config
+	globalBalance   *model.GlobalBalanceConfig
 }
 
-func NewSpellServiceImpl(dao persistence.SpellRepository, userService UserService, npcService NpcService, messageService MessageService, objectService ObjectService, intervals IntervalService, trainingService TrainingService, areaService AreaService, cfg *config.Config) SpellService {
+// spellField is an area spell that keeps hitting whoever stands in it, like a fire wall.
+type spellField struct {
+	caster  *model.Character
+	spell   *model.Spell
+	center  model.Position
+	expires time.Time
+	nextHit time.Time
+}
+
+func NewSpellServiceImpl(dao persistence.SpellRepository, userService UserService, npcService NpcService, messageService MessageService, objectService ObjectService, intervals IntervalService, trainingService TrainingService, areaService AreaService, questService QuestService, duelService DuelService, lootService LootService, effectService EffectService, cfg *config.Config, globalBalance *model.GlobalBalanceConfig) SpellService {
 	return &SpellServiceImpl{
 		dao:             dao,
 		userService:     userService,
@@ -35,8 +53,13 @@ func NewSpellServiceImpl(dao persistence.SpellRepository, userService UserServic
 		intervals:       intervals,
 		trainingService: trainingService,
 		areaService:     areaService,
+		questService:    questService,
+		duelService:     duelService,
+		lootService:     lootService,
+		effectService:   effectService,
 		spells:          make(map[int]*model.Spell),
 		config:          cfg,
+		globalBalance:   globalBalance,
 	}
 }
 
@@ -121,6 +144,18 @@ func (s *SpellServiceImpl) CastSpell(caster *model.Character, spellID int, targe
 		return
 	}
 
+	if !s.canTarget(caster, target, spell) {
+		return
+	}
+
+	if !s.hasStaffPower(caster, spell) {
+		return
+	}
+
+	if !s.canReach(caster, target, spell) {
+		return
+	}
+
 	// Consume resources
 	caster.Mana -= spell.ManaRequired
 	caster.Stamina -= spell.StaminaRequired
@@ -151,72 +186,172 @@ func (s *SpellServiceImpl) CastSpell(caster *model.Character, spellID int, targe
 		})
 	}
 
+	// Attacking gives a disguise away, once the cast is sure to go off
+	if isOffensive(spell) {
+		s.effectService.Dispel(caster, model.EffectMimicry)
+	}
+
 	// Resolve effect on target
+	switch t := target.(type) {
+	case *model.Character:
+		s.applySpellToCharacter(caster, t, spell)
+	case *model.WorldNPC:
+		s.applySpellToNPC(caster, t, spell)
+	case model.Position:
+		s.applySpellToPosition(caster, t, spell)
+	default:
+		return
+	}
+	s.trainingService.ImproveSkill(caster, model.Magic)
+}
+
+// canTarget checks the spell may be cast on target, so refused casts cost nothing.
+func (s *SpellServiceImpl) canTarget(caster *model.Character, target any, spell *model.Spell) bool {
+	mapService := s.messageService.MapService()
+
 	switch t := target.(type) {
 	case *model.Character:
 		fmt.Printf("CastSpell: Target is Character %s. Spell Type: %d\n", t.Name, spell.TargetType)
-		if spell.TargetType == model.TargetUser || spell.TargetType == model.TargetUserAndNpc {
-			isOffensive := spell.SubeHP == 2 || spell.Paralyzes || spell.Immobilizes || spell.Poison
-			if isOffensive {
-				if s.messageService.MapService().IsSafeZone(caster.Position) || s.messageService.MapService().IsSafeZone(t.Position) ||
-					!s.messageService.MapService().IsPkMap(caster.Position.Map) || !s.messageService.MapService().IsPkMap(t.Position.Map) {
-					if conn != nil {
-						conn.Send(&outgoing.ConsoleMessagePacket{
-							Message: "No puedes combatir en zona segura.",
-							Font:    outgoing.INFO,
-						})
-					}
-					return
-				}
-			}
-			s.applySpellToCharacter(caster, t, spell)
-		} else {
+		if spell.TargetType != model.TargetUser && spell.TargetType != model.TargetUserAndNpc {
 			fmt.Println("CastSpell: Invalid target type for Character")
-			if conn != nil {
-				conn.Send(&outgoing.ConsoleMessagePacket{Message: "Target inválido.", Font: outgoing.INFO})
+			s.messageService.SendConsoleMessage(caster, "Target inválido.", outgoing.INFO)
+			return false
+		}
+		if isOffensive(spell) && !s.duelService.IsConsensualFight(caster, t) {
+			if mapService.IsSafeZone(caster.Position) || mapService.IsSafeZone(t.Position) ||
+				!mapService.IsPkMap(caster.Position.Map) || !mapService.IsPkMap(t.Position.Map) {
+				s.messageService.SendConsoleMessage(caster, "No puedes combatir en zona segura.", outgoing.INFO)
+				return false
 			}
 		}
 	case *model.WorldNPC:
 		fmt.Printf("CastSpell: Target is NPC. Spell Type: %d\n", spell.TargetType)
-		if spell.TargetType == model.TargetNpc || spell.TargetType == model.TargetUserAndNpc {
-			// Safe zone check for offensive spells on NPCs
-			isOffensive := spell.SubeHP == 2 || spell.Paralyzes || spell.Immobilizes || spell.Poison
-			if isOffensive {
-				if s.messageService.MapService().IsSafeZone(caster.Position) || s.messageService.MapService().IsSafeZone(t.Position) {
-					if conn != nil {
-						conn.Send(&outgoing.ConsoleMessagePacket{
-							Message: "No puedes combatir en zona segura.",
-							Font:    outgoing.INFO,
-						})
-					}
-					return
-				}
-			}
-			s.applySpellToNPC(caster, t, spell)
-		} else {
+		if spell.TargetType != model.TargetNpc && spell.TargetType != model.TargetUserAndNpc {
 			fmt.Println("CastSpell: Invalid target type for NPC")
-			if conn != nil {
-				conn.Send(&outgoing.ConsoleMessagePacket{Message: "Target inválido.", Font: outgoing.INFO})
-			}
+			s.messageService.SendConsoleMessage(caster, "Target inválido.", outgoing.INFO)
+			return false
+		}
+		// Safe zone check for offensive spells on NPCs
+		if isOffensive(spell) && (mapService.IsSafeZone(caster.Position) || mapService.IsSafeZone(t.Position)) {
+			s.messageService.SendConsoleMessage(caster, "No puedes combatir en zona segura.", outgoing.INFO)
+			return false
 		}
 	case model.Position:
 		fmt.Printf("CastSpell: Target is Position. Spell Type: %d\n", spell.TargetType)
-		if spell.TargetType == model.TargetTerrain {
-			s.applySpellToPosition(caster, t, spell)
-		} else {
+		if spell.TargetType != model.TargetTerrain {
 			fmt.Println("CastSpell: Invalid target type for Position")
-			if conn != nil {
-				conn.Send(&outgoing.ConsoleMessagePacket{Message: "Debes seleccionar un objetivo.", Font: outgoing.INFO})
-			}
+			s.messageService.SendConsoleMessage(caster, "Debes seleccionar un objetivo.", outgoing.INFO)
+			return false
+		}
+		// Area spells pick their victims one by one, but can't be thrown from a safe zone
+		if isOffensive(spell) && (spell.AreaRadius > 0 || spell.FieldDuration > 0) && mapService.IsSafeZone(caster.Position) {
+			s.messageService.SendConsoleMessage(caster, "No puedes combatir en zona segura.", outgoing.INFO)
+			return false
 		}
 	default:
 		fmt.Println("CastSpell: Unknown target type")
 		// Target self if no target specified or invalid, usually handled by skill service logic before calling this.
+		return false
 	}
+	return true
+}
+
+// hasStaffPower tells whether the caster wields what the spell needs. Only mages are bound to
+// staffs, other classes cast without one.
+func (s *SpellServiceImpl) hasStaffPower(caster *model.Character, spell *model.Spell) bool {
+	if spell.NeedStaff <= 0 || caster.Archetype != model.Mage {
+		return true
+	}
+
+	staff := s.objectService.GetEquipped(caster, model.OTWeapon)
+	if staff == nil || staff.MagicPower == 0 {
+		s.messageService.SendConsoleMessage(caster, "No puedes lanzar este conjuro sin la ayuda de un báculo.", outgoing.INFO)
+		return false
+	}
+	if staff.MagicPower < spell.NeedStaff {
+		s.messageService.SendConsoleMessage(caster, "Tu báculo no es lo suficientemente poderoso para lanzar este conjuro.", outgoing.INFO)
+		return false
+	}
+	return true
+}
+
+// staffDamage adds the bonus of the caster's staff to the damage of staff affected spells.
+func (s *SpellServiceImpl) staffDamage(caster *model.Character, spell *model.Spell, damage int) int {
+	if !spell.StaffAffected {
+		return damage
+	}
+	if staff := s.objectService.GetEquipped(caster, model.OTWeapon); staff != nil && staff.StaffDamageBonus > 0 {
+		damage += damage * staff.StaffDamageBonus / 100
+	}
+	return damage
+}
+
+// magicDefense rolls how much spell damage the target's rings, hats and armor absorb.
+func (s *SpellServiceImpl) magicDefense(target *model.Character) int {
+	defense := 0
+	for i := 0; i < model.InventorySlots; i++ {
+		slot := target.Inventory.GetSlot(i)
+		if !slot.Equipped {
+			continue
+		}
+		if obj := s.objectService.GetObject(slot.ObjectID); obj != nil && obj.MaxMagicDef > 0 {
+			defense += utils.RandomNumber(obj.MinMagicDef, obj.MaxMagicDef)
+		}
+	}
+	return defense
+}
+
+// canReach rejects targets the caster can't see or that are out of the spell's range, so clients
+// can't cast on anything they click on the map.
+func (s *SpellServiceImpl) canReach(caster *model.Character, target any, spell *model.Spell) bool {
+	var targetPos model.Position
+	switch t := target.(type) {
+	case *model.Character:
+		if t == caster {
+			return true
+		}
+		targetPos = t.Position
+	case *model.WorldNPC:
+		targetPos = t.Position
+	case model.Position:
+		targetPos = t
+		targetPos.Map = caster.Position.Map
+	default:
+		return true
+	}
+
+	maxRange := spell.Range
+	if maxRange <= 0 {
+		maxRange = s.globalBalance.SpellRange
+	}
+
+	gameMap := s.messageService.MapService().GetMap(caster.Position.Map)
+	if gameMap == nil || !s.areaService.InRange(caster.Position, targetPos) || caster.Position.GetDistance(targetPos) > maxRange {
+		s.messageService.SendConsoleMessage(caster, "Estás demasiado lejos.", outgoing.INFO)
+		return false
+	}
+
+	inSight := false
+	gameMap.View(func(m *model.Map) {
+		inSight = m.HasLineOfSight(caster.Position, targetPos)
+	})
+	if !inSight {
+		s.messageService.SendConsoleMessage(caster, "No tienes línea de visión con el objetivo.", outgoing.INFO)
+		return false
+	}
+	return true
 }
 
 func (s *SpellServiceImpl) applySpellToCharacter(caster *model.Character, target *model.Character, spell *model.Spell) {
-	s.applySpellEffectToCharacter(target, spell, caster.Name)
+	if spell.Mimic {
+		if target == caster || target.Dead {
+			s.messageService.SendConsoleMessage(caster, "No puedes copiar esa apariencia.", outgoing.INFO)
+			return
+		}
+		s.mimic(caster, target.Body, target.Head, target.Name)
+		return
+	}
+	s.applySpellEffectToCharacter(target, spell, caster.Name, caster)
 }
 
 func (s *SpellServiceImpl) NpcLanzaSpellSobreUser(npc *model.WorldNPC, target *model.Character, spellID int) bool {
@@ -231,8 +366,7 @@ func (s *SpellServiceImpl) NpcLanzaSpellSobreUser(npc *model.WorldNPC, target *m
 	}
 
 	// Safe zone check for offensive spells
-	isOffensive := spell.SubeHP == 2 || spell.Paralyzes || spell.Immobilizes || spell.Poison
-	if isOffensive {
+	if isOffensive(spell) {
 		if s.messageService.MapService().IsSafeZone(npc.Position) || s.messageService.MapService().IsSafeZone(target.Position) ||
 			!s.messageService.MapService().IsPkMap(npc.Position.Map) || !s.messageService.MapService().IsPkMap(target.Position.Map) {
 			return false
@@ -252,7 +386,7 @@ func (s *SpellServiceImpl) NpcLanzaSpellSobreUser(npc *model.WorldNPC, target *m
 	if spell.TargetType == model.TargetUser || spell.TargetType == model.TargetUserAndNpc {
 		// We need a version of applySpellToCharacter that doesn't require a 'caster' *model.Character
 		// or we use a dummy caster. Let's refactor slightly.
-		s.applySpellEffectToCharacter(target, spell, npc.NPC.Name)
+		s.applySpellEffectToCharacter(target, spell, npc.NPC.Name, nil)
 	}
 
 	// Update interval
@@ -261,7 +395,8 @@ func (s *SpellServiceImpl) NpcLanzaSpellSobreUser(npc *model.WorldNPC, target *m
 	return true
 }
 
-func (s *SpellServiceImpl) applySpellEffectToCharacter(target *model.Character, spell *model.Spell, casterName string) {
+// applySpellEffectToCharacter applies a spell cast by a player (caster) or an NPC (caster is nil).
+func (s *SpellServiceImpl) applySpellEffectToCharacter(target *model.Character, spell *model.Spell, casterName string, caster *model.Character) {
 	// FX
 	s.messageService.SendToArea(&outgoing.CreateFxPacket{
 		CharIndex: target.CharIndex,
@@ -294,6 +429,10 @@ func (s *SpellServiceImpl) applySpellEffectToCharacter(target *model.Character,
 	// Damage
 	if spell.SubeHP == 2 {
 		amount := rand.Intn(spell.MaxHP-spell.MinHP+1) + spell.MinHP
+		if caster != nil {
+			amount = s.staffDamage(caster, spell, amount)
+		}
+		amount = max(amount-s.magicDefense(target), 0)
 		target.Hp -= amount
 		if target.Hp < 0 {
 			target.Hp = 0
@@ -302,49 +441,154 @@ func (s *SpellServiceImpl) applySpellEffectToCharacter(target *model.Character,
 		s.messageService.SendConsoleMessage(target, fmt.Sprintf("¡%s te quitó %d puntos de vida!", casterName, amount), outgoing.FIGHT)
 
 		if target.Hp <= 0 {
+			if caster != nil && s.duelService.IsConsensualFight(caster, target) && s.duelService.HandleDefeat(caster, target) {
+				return
+			}
 			s.messageService.HandleDeath(target, "")
 		}
 	}
 
 	// Paralysis / Immobilize
-	wasParalyzed := target.Paralyzed || target.Immobilized
+	paralysisTime := time.Duration(s.globalBalance.IntervalParalyzed) * time.Millisecond
 	if spell.Paralyzes {
-		target.Paralyzed = true
-		target.ParalyzedSince = time.Now()
+		s.effectService.Apply(target, model.EffectParalysis, 0, paralysisTime)
 		s.messageService.SendConsoleMessage(target, "¡Te han paralizado!", outgoing.INFO)
 	}
 	if spell.Immobilizes {
-		target.Immobilized = true
-		target.ParalyzedSince = time.Now()
+		s.effectService.Apply(target, model.EffectImmobilization, 0, paralysisTime)
 		s.messageService.SendConsoleMessage(target, "¡Te han inmovilizado!", outgoing.INFO)
 	}
 
 	if spell.RemoveParalysis {
-		target.Paralyzed = false
-		target.Immobilized = false
-		target.ParalyzedSince = time.Time{}
+		s.effectService.Dispel(target, model.EffectParalysis)
+		s.effectService.Dispel(target, model.EffectImmobilization)
 		s.messageService.SendConsoleMessage(target, "¡Has recuperado el movimiento!", outgoing.INFO)
 	}
 
-	isParalyzed := target.Paralyzed || target.Immobilized
-	if isParalyzed != wasParalyzed {
-		if conn := s.userService.GetConnection(target); conn != nil {
-			conn.Send(&outgoing.ParalyzeOkPacket{})
-		}
-	}
-
 	// Poison
 	if spell.Poison {
-		target.Poisoned = true
+		s.effectService.Apply(target, model.EffectPoison, model.PoisonDamage, 0)
 		s.messageService.SendConsoleMessage(target, "¡Te han envenenado!", outgoing.INFO)
 	}
 
+	// Strength and agility
+	if spell.SubeStrength != 0 {
+		s.changeAttribute(target, model.EffectStrength, spell.SubeStrength, spell.MinStrength, spell.MaxStrength)
+		if spell.SubeStrength == 1 {
+			s.messageService.SendConsoleMessage(target, "¡Tu fuerza ha aumentado!", outgoing.INFO)
+		} else {
+			s.messageService.SendConsoleMessage(target, "¡Tu fuerza ha disminuido!", outgoing.INFO)
+		}
+	}
+	if spell.SubeAgility != 0 {
+		s.changeAttribute(target, model.EffectAgility, spell.SubeAgility, spell.MinAgility, spell.MaxAgility)
+		if spell.SubeAgility == 1 {
+			s.messageService.SendConsoleMessage(target, "¡Tu agilidad ha aumentado!", outgoing.INFO)
+		} else {
+			s.messageService.SendConsoleMessage(target, "¡Tu agilidad ha disminuido!", outgoing.INFO)
+		}
+	}
+
+	// Mana and stamina
+	if spell.SubeMana != 0 {
+		amount := changeStat(&target.Mana, target.MaxMana, spell.SubeMana, spell.MinMana, spell.MaxMana)
+		if spell.SubeMana == 1 {
+			s.messageService.SendConsoleMessage(target, fmt.Sprintf("Has recuperado %d puntos de maná.", amount), outgoing.FIGHT)
+		} else {
+			s.messageService.SendConsoleMessage(target, fmt.Sprintf("¡%s te quitó %d puntos de maná!", casterName, amount), outgoing.FIGHT)
+		}
+	}
+	if spell.SubeStamina != 0 {
+		amount := changeStat(&target.Stamina, target.MaxStamina, spell.SubeStamina, spell.MinStamina, spell.MaxStamina)
+		if spell.SubeStamina == 1 {
+			s.messageService.SendConsoleMessage(target, fmt.Sprintf("Has recuperado %d puntos de energía.", amount), outgoing.FIGHT)
+		} else {
+			s.messageService.SendConsoleMessage(target, fmt.Sprintf("¡%s te quitó %d puntos de energía!", casterName, amount), outgoing.FIGHT)
+		}
+	}
+
 	conn := s.userService.GetConnection(target)
+
+	// Hunger and thirst
+	if spell.SubeHunger != 0 || spell.SubeThirst != 0 {
+		if spell.SubeHunger != 0 {
+			changeStat(&target.Hunger, 100, spell.SubeHunger, spell.MinHunger, spell.MaxHunger)
+			if spell.SubeHunger == 1 {
+				s.messageService.SendConsoleMessage(target, "Te sientes menos hambriento.", outgoing.INFO)
+			} else {
+				s.messageService.SendConsoleMessage(target, fmt.Sprintf("¡%s te ha provocado hambre!", casterName), outgoing.INFO)
+			}
+		}
+		if spell.SubeThirst != 0 {
+			changeStat(&target.Thirstiness, 100, spell.SubeThirst, spell.MinThirst, spell.MaxThirst)
+			if spell.SubeThirst == 1 {
+				s.messageService.SendConsoleMessage(target, "Te sientes menos sediento.", outgoing.INFO)
+			} else {
+				s.messageService.SendConsoleMessage(target, fmt.Sprintf("¡%s te ha provocado sed!", casterName), outgoing.INFO)
+			}
+		}
+		if conn != nil {
+			conn.Send(&outgoing.UpdateHungerAndThirstPacket{
+				MinHunger: target.Hunger, MaxHunger: 100,
+				MinThirst: target.Thirstiness, MaxThirst: 100,
+			})
+		}
+	}
+
 	if conn != nil {
 		conn.Send(outgoing.NewUpdateUserStatsPacket(target))
 	}
 }
 
+// isOffensive tells whether a spell harms its target, which isn't allowed in safe zones.
+func isOffensive(spell *model.Spell) bool {
+	return spell.SubeHP == 2 || spell.Paralyzes || spell.Immobilizes || spell.Poison ||
+		spell.SubeMana == 2 || spell.SubeStamina == 2 || spell.SubeHunger == 2 || spell.SubeThirst == 2 ||
+		spell.SubeAgility == 2 || spell.SubeStrength == 2
+}
+
+// mimic disguises the caster with the given looks for a while, hiding its name. Casting it again
+// while disguised changes the disguise but keeps the caster's own looks to go back to.
+func (s *SpellServiceImpl) mimic(caster *model.Character, body, head int, name string) {
+	if caster.Sailing {
+		s.messageService.SendConsoleMessage(caster, "No puedes mimetizarte mientras navegas.", outgoing.INFO)
+		return
+	}
+
+	if !caster.HasEffect(model.EffectMimicry) {
+		caster.UndisguisedBody = caster.Body
+		caster.UndisguisedHead = caster.Head
+	}
+	caster.Body = body
+	caster.Head = head
+	s.effectService.Apply(caster, model.EffectMimicry, 0, time.Duration(s.globalBalance.MimicryDuration)*time.Second)
+
+	refreshAppearance(s.messageService, caster)
+	s.messageService.SendConsoleMessage(caster, fmt.Sprintf("Has adquirido la apariencia de %s.", name), outgoing.INFO)
+}
+
+// changeAttribute raises (sube 1) or lowers (sube 2) an attribute for a while.
+func (s *SpellServiceImpl) changeAttribute(target *model.Character, effectType model.EffectType, sube, min, max int) {
+	amount := utils.RandomNumber(min, max)
+	if sube == 2 {
+		amount = -amount
+	}
+	s.effectService.Apply(target, effectType, amount, time.Duration(s.globalBalance.SpellAttributeDuration)*time.Second)
+}
+
+// changeStat raises (sube 1) or lowers (sube 2) a stat between 0 and limit by a random amount
+// in [min, max], returning the points actually changed.
+func changeStat(stat *int, limit, sube, min, max int) int {
+	old := *stat
+	amount := utils.RandomNumber(min, max)
+	if sube == 1 {
+		*stat = utils.Min(*stat+amount, limit)
+		return *stat - old
+	}
+	*stat = utils.Max(*stat-amount, 0)
+	return old - *stat
+}
+
 func (s *SpellServiceImpl) PriestHealUser(target *model.Character) {
 	// SND_CURAR_SACERDOTE = 13 (example)
 	s.messageService.SendToArea(&outgoing.PlayWavePacket{
@@ -359,7 +603,7 @@ func (s *SpellServiceImpl) PriestHealUser(target *model.Character) {
 	// If newbie, restore everything
 	if target.Level <= 13 { // Assuming newbie level <= 13
 		target.Mana = target.MaxMana
-		target.Poisoned = false
+		s.effectService.Dispel(target, model.EffectPoison)
 		s.messageService.SendConsoleMessage(target, "El sacerdote te ha restaurado el mana completamente.", outgoing.INFO)
 	}
 
@@ -393,8 +637,13 @@ func (s *SpellServiceImpl) PriestResucitateUser(target *model.Character) {
 }
 
 func (s *SpellServiceImpl) applySpellToNPC(caster *model.Character, target *model.WorldNPC, spell *model.Spell) {
+	if spell.Mimic {
+		s.mimic(caster, target.NPC.Body, target.NPC.Head, target.NPC.Name)
+		return
+	}
 
-	if !target.NPC.Hostile {
+	// Peaceful creatures only take the caster's help when they are its pets
+	if !target.NPC.Hostile && (isOffensive(spell) || target.OwnerIndex != int(caster.CharIndex)) {
 		s.messageService.SendConsoleMessage(caster, "No puedes atacar a una criatura pacífica.", outgoing.INFO)
 		return
 	}
@@ -409,13 +658,14 @@ func (s *SpellServiceImpl) applySpellToNPC(caster *model.Character, target *mode
 	// Heal
 	if spell.SubeHP == 1 {
 		amount := rand.Intn(spell.MaxHP-spell.MinHP+1) + spell.MinHP
-		target.HP += amount
+		target.HP = min(target.HP+amount, target.NPC.MaxHp)
 		s.messageService.SendConsoleMessage(caster, fmt.Sprintf("Has curado %d puntos a la criatura.", amount), outgoing.FIGHT)
 	}
 
 	// Damage
 	if spell.SubeHP == 2 {
 		amount := rand.Intn(spell.MaxHP-spell.MinHP+1) + spell.MinHP
+		amount = max(s.staffDamage(caster, spell, amount)-target.NPC.MagicDefense, 0)
 
 		// Grant experience proportional to damage
 		s.grantExperience(caster, target, amount)
@@ -429,12 +679,13 @@ func (s *SpellServiceImpl) applySpellToNPC(caster *model.Character, target *mode
 	}
 
 	// Paralysis
-	if spell.Paralyzes {
+	if (spell.Paralyzes || spell.Immobilizes) && target.NPC.ParalysisImmune {
+		s.messageService.SendConsoleMessage(caster, "La criatura es inmune a la parálisis.", outgoing.INFO)
+	} else if spell.Paralyzes {
 		target.Paralyzed = true
 		target.ParalyzedSince = time.Now()
 		s.messageService.SendConsoleMessage(caster, "Has paralizado a la criatura.", outgoing.INFO)
-	}
-	if spell.Immobilizes {
+	} else if spell.Immobilizes {
 		target.Immobilized = true
 		target.ParalyzedSince = time.Now()
 		s.messageService.SendConsoleMessage(caster, "Has inmovilizado a la criatura.", outgoing.INFO)
@@ -459,31 +710,9 @@ func (s *SpellServiceImpl) handleNpcDeath(caster *model.Character, target *model
 		s.trainingService.CheckLevel(caster)
 	}
 
-	// Drop items
-	for _, drop := range target.NPC.Drops {
-		obj := s.objectService.GetObject(drop.ObjectID)
-		if obj != nil {
-
-			// Check if drop position is valid
-			dropPos := target.Position
-			validPos := s.messageService.MapService().IsInPlayableArea(int(dropPos.X), int(dropPos.Y)) && !s.messageService.MapService().IsBlocked(dropPos.Map, int(dropPos.X), int(dropPos.Y))
+	s.questService.OnNpcKilled(caster, target)
 
-			if validPos {
-				worldObj := &model.WorldObject{
-					Object: obj,
-					Amount: drop.Amount,
-				}
-				if s.messageService.MapService().GetObjectAt(target.Position) == nil {
-					s.messageService.MapService().PutObject(target.Position, worldObj)
-					s.messageService.SendToArea(&outgoing.ObjectCreatePacket{
-						X:            target.Position.X,
-						Y:            target.Position.Y,
-						GraphicIndex: int16(obj.GraphicIndex),
-					}, target.Position)
-				}
-			}
-		}
-	}
+	s.lootService.DropNpcLoot(caster, target)
 
 	s.npcService.RemoveNPC(target, s.messageService.MapService())
 }
@@ -516,11 +745,260 @@ func (s *SpellServiceImpl) grantExperience(attacker *model.Character, victim *mo
 func (s *SpellServiceImpl) applySpellToPosition(caster *model.Character, pos model.Position, spell *model.Spell) {
 	// Summon
 	if spell.SummonNPC > 0 {
-		// Simple summon logic
-		// Need NpcService to spawn? SpellService doesn't have NpcService.
-		// I should inject it. Or expose SpawnNpc in MapService?
-		// NpcService is best.
-		// For now, print message "Summon not implemented fully".
-		s.messageService.SendConsoleMessage(caster, "Invocación no implementada por completo.", outgoing.INFO)
+		s.summonCreatures(caster, pos, spell)
+	}
+
+	// Area
+	if spell.AreaRadius > 0 || spell.FieldDuration > 0 {
+		pos.Map = caster.Position.Map
+
+		s.messageService.SendToArea(&outgoing.PlayWavePacket{
+			Wave: byte(spell.WAV),
+			X:    pos.X,
+			Y:    pos.Y,
+		}, pos)
+		s.applySpellToArea(caster, pos, spell)
+
+		if spell.FieldDuration > 0 {
+			now := time.Now()
+			field := &spellField{
+				caster:  caster,
+				spell:   spell,
+				center:  pos,
+				expires: now.Add(time.Duration(spell.FieldDuration) * time.Second),
+				nextHit: now.Add(time.Duration(s.globalBalance.SpellFieldInterval) * time.Millisecond),
+			}
+			s.fieldsMu.Lock()
+			s.fields = append(s.fields, field)
+			s.fieldsMu.Unlock()
+			s.showField(field, true)
+		}
+	}
+}
+
+// applySpellToArea casts the spell on everyone within its radius of center. Offensive spells only
+// hit foes outside safe zones, the rest only reach the caster's allies and pets.
+func (s *SpellServiceImpl) applySpellToArea(caster *model.Character, center model.Position, spell *model.Spell) {
+	m := s.messageService.MapService().GetMap(center.Map)
+	if m == nil {
+		return
+	}
+
+	// Collect first, spells can kill and remove NPCs from the map
+	var chars []*model.Character
+	var npcs []*model.WorldNPC
+	m.View(func(m *model.Map) {
+		for y := max(int(center.Y)-spell.AreaRadius, 0); y <= min(int(center.Y)+spell.AreaRadius, model.MapHeight-1); y++ {
+			for x := max(int(center.X)-spell.AreaRadius, 0); x <= min(int(center.X)+spell.AreaRadius, model.MapWidth-1); x++ {
+				tile := m.GetTile(x, y)
+				if tile.Character != nil {
+					chars = append(chars, tile.Character)
+				}
+				if tile.NPC != nil {
+					npcs = append(npcs, tile.NPC)
+				}
+			}
+		}
+	})
+
+	offensive := isOffensive(spell)
+	for _, target := range chars {
+		if (target.Dead && !spell.Revive) || offensive != s.isAreaFoe(caster, target) {
+			continue
+		}
+		s.applySpellEffectToCharacter(target, spell, caster.Name, caster)
+	}
+
+	for _, npc := range npcs {
+		if npc.HP <= 0 {
+			continue
+		}
+		pet := npc.OwnerIndex == int(caster.CharIndex)
+		if offensive && (!npc.NPC.Hostile || pet || s.messageService.MapService().IsSafeZone(npc.Position)) {
+			continue
+		}
+		if !offensive && !pet {
+			continue
+		}
+		s.applySpellToNPC(caster, npc, spell)
+	}
+}
+
+// isAreaFoe tells whether an area spell of the caster may hurt target: duel rivals always, otherwise
+// characters of the other side (citizen or criminal) where fighting is allowed.
+func (s *SpellServiceImpl) isAreaFoe(caster, target *model.Character) bool {
+	if target == caster {
+		return false
+	}
+	if s.duelService.IsConsensualFight(caster, target) {
+		return true
+	}
+	if caster.Faccion.Criminal == target.Faccion.Criminal {
+		return false
+	}
+	mapService := s.messageService.MapService()
+	return !mapService.IsSafeZone(target.Position) && mapService.IsPkMap(target.Position.Map)
+}
+
+// ProcessFields hits whoever stands in the spell fields due and drops the expired ones.
+// Fields also go away when their caster logs out, dies or leaves the map.
+func (s *SpellServiceImpl) ProcessFields(now time.Time) {
+	s.fieldsMu.Lock()
+	var due, expired []*spellField
+	active := s.fields[:0]
+	for _, field := range s.fields {
+		if !now.Before(field.expires) || s.userService.GetConnection(field.caster) == nil ||
+			field.caster.Dead || field.caster.Position.Map != field.center.Map {
+			expired = append(expired, field)
+			continue
+		}
+		active = append(active, field)
+		if !now.Before(field.nextHit) {
+			field.nextHit = now.Add(time.Duration(s.globalBalance.SpellFieldInterval) * time.Millisecond)
+			due = append(due, field)
+		}
+	}
+	clear(s.fields[len(active):])
+	s.fields = active
+	s.fieldsMu.Unlock()
+
+	for _, field := range expired {
+		s.showField(field, false)
+	}
+	for _, field := range due {
+		// Shown again on every hit so players walking in late see it too
+		s.showField(field, true)
+		s.applySpellToArea(field.caster, field.center, field.spell)
+	}
+}
+
+// showField draws the field's graphic on its tiles, or clears it. Tiles holding a real object
+// are left alone so the field never hides nor erases it.
+func (s *SpellServiceImpl) showField(field *spellField, visible bool) {
+	if field.spell.FieldGraphic <= 0 {
+		return
+	}
+	mapService := s.messageService.MapService()
+	radius := field.spell.AreaRadius
+	center := field.center
+	for y := max(int(center.Y)-radius, 0); y <= min(int(center.Y)+radius, model.MapHeight-1); y++ {
+		for x := max(int(center.X)-radius, 0); x <= min(int(center.X)+radius, model.MapWidth-1); x++ {
+			pos := model.Position{Map: center.Map, X: byte(x), Y: byte(y)}
+			if mapService.GetObjectAt(pos) != nil {
+				continue
+			}
+			if visible {
+				s.messageService.SendToArea(&outgoing.ObjectCreatePacket{
+					X:            pos.X,
+					Y:            pos.Y,
+					GraphicIndex: int16(field.spell.FieldGraphic),
+				}, pos)
+			} else {
+				s.messageService.SendToArea(&outgoing.ObjectDeletePacket{X: pos.X, Y: pos.Y}, pos)
+			}
+		}
+	}
+}
+
+// summonCreatures spawns the spell's creatures around pos as pets of the caster.
+func (s *SpellServiceImpl) summonCreatures(caster *model.Character, pos model.Position, spell *model.Spell) {
+	amount := min(max(spell.SummonAmount, 1), model.MaxPets-len(s.npcService.GetPets(int(caster.CharIndex))))
+	if amount <= 0 {
+		s.messageService.SendConsoleMessage(caster, "No puedes controlar más criaturas.", outgoing.INFO)
+		return
+	}
+
+	pos.Map = caster.Position.Map
+	for i := 0; i < amount; i++ {
+		npc := s.messageService.MapService().SpawnNpcAt(spell.SummonNPC, pos)
+		if npc == nil {
+			break
+		}
+		s.npcService.SetOwner(npc, int(caster.CharIndex))
+		npc.Respawn = false
+		s.areaService.BroadcastToArea(npc.Position, &outgoing.NpcCreatePacket{Npc: npc})
+	}
+}
+
+func (s *SpellServiceImpl) SendSpellInfo(char *model.Character, slot int) {
+	if slot < 0 || slot >= len(char.Spells) {
+		return
+	}
+
+	spell := s.GetSpell(char.Spells[slot])
+	if spell == nil {
+		return
+	}
+
+	s.messageService.SendConsoleMessage(char, "%%%%%% INFO DEL HECHIZO %%%%%%", outgoing.INFO)
+	s.messageService.SendConsoleMessage(char, fmt.Sprintf("Nombre: %s", spell.Name), outgoing.INFO)
+	s.messageService.SendConsoleMessage(char, fmt.Sprintf("Descripción: %s", spell.Description), outgoing.INFO)
+	s.messageService.SendConsoleMessage(char, fmt.Sprintf("Skill requerido: %d de magia.", spell.MinSkill), outgoing.INFO)
+	s.messageService.SendConsoleMessage(char, fmt.Sprintf("Maná necesario: %d", spell.ManaRequired), outgoing.INFO)
+	s.messageService.SendConsoleMessage(char, fmt.Sprintf("Energía necesaria: %d", spell.StaminaRequired), outgoing.INFO)
+	s.messageService.SendConsoleMessage(char, "%%%%%%%%%%%%%%%%%%%%%%%%", outgoing.INFO)
+}
+
+func (s *SpellServiceImpl) MoveSpell(char *model.Character, slot int, upwards bool) {
+	if slot < 0 || slot >= len(char.Spells) {
+		return
+	}
+
+	other := slot + 1
+	if upwards {
+		other = slot - 1
+	}
+
+	// Moving past the top or bottom of the spellbook is a no-op
+	if other < 0 || other >= len(char.Spells) {
+		return
+	}
+
+	char.Spells[slot], char.Spells[other] = char.Spells[other], char.Spells[slot]
+
+	s.syncSpellSlot(char, slot)
+	s.syncSpellSlot(char, other)
+}
+
+func (s *SpellServiceImpl) ForgetSpell(char *model.Character, slot int) {
+	if slot < 0 || slot >= len(char.Spells) {
+		return
+	}
+
+	spellID := char.Spells[slot]
+	char.Spells = append(char.Spells[:slot], char.Spells[slot+1:]...)
+
+	if char.SelectedSpell == spellID {
+		char.SelectedSpell = 0
 	}
+
+	// Every slot after the removed one shifted up, resync them including the now empty last slot
+	for i := slot; i <= len(char.Spells); i++ {
+		s.syncSpellSlot(char, i)
+	}
+
+	if spell := s.GetSpell(spellID); spell != nil {
+		s.messageService.SendConsoleMessage(char, fmt.Sprintf("Has olvidado el hechizo %s.", spell.Name), outgoing.INFO)
+	}
+}
+
+func (s *SpellServiceImpl) syncSpellSlot(char *model.Character, slot int) {
+	conn := s.userService.GetConnection(char)
+	if conn == nil {
+		return
+	}
+
+	packet := &outgoing.ChangeSpellSlotPacket{
+		Slot:      byte(slot + 1),
+		SpellName: "(Vacío)",
+	}
+
+	if slot < len(char.Spells) {
+		packet.SpellID = int16(char.Spells[slot])
+		if spell := s.GetSpell(char.Spells[slot]); spell != nil {
+			packet.SpellName = spell.Name
+		}
+	}
+
+	conn.Send(packet)
 }
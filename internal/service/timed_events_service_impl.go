@@ -1,6 +1,7 @@
 package service
 
 import (
+	"fmt"
 	"log/slog"
 	"time"
 
@@ -11,28 +12,37 @@ import (
 )
 
 type TimedEventsServiceImpl struct {
-	userService    UserService
-	messageService MessageService
-	loginService   LoginService
-	config         *config.Config
-	globalBalance  *model.GlobalBalanceConfig
-	stopChan       chan struct{}
+	userService     UserService
+	messageService  MessageService
+	loginService    LoginService
+	commerceService CommerceService
+	trainingService TrainingService
+	effectService   EffectService
+	duelService     DuelService
+	config          *config.Config
+	globalBalance   *model.GlobalBalanceConfig
+	stopChan        chan struct{}
 }
 
-func NewTimedEventsServiceImpl(userService UserService, messageService MessageService, loginService LoginService, cfg *config.Config, globalBalance *model.GlobalBalanceConfig) TimedEventsService {
+func NewTimedEventsServiceImpl(userService UserService, messageService MessageService, loginService LoginService, commerceService CommerceService, trainingService TrainingService, effectService EffectService, duelService DuelService, cfg *config.Config, globalBalance *model.GlobalBalanceConfig) TimedEventsService {
 	return &TimedEventsServiceImpl{
-		userService:    userService,
-		messageService: messageService,
-		loginService:   loginService,
-		config:         cfg,
-		globalBalance:  globalBalance,
-		stopChan:       make(chan struct{}),
+		userService:     userService,
+		messageService:  messageService,
+		loginService:    loginService,
+		commerceService: commerceService,
+		trainingService: trainingService,
+		effectService:   effectService,
+		duelService:     duelService,
+		config:          cfg,
+		globalBalance:   globalBalance,
+		stopChan:        make(chan struct{}),
 	}
 }
 
 func (s *TimedEventsServiceImpl) Start() {
 	go s.regenLoop()
 	go s.worldSaveLoop()
+	go s.restockLoop()
 }
 
 func (s *TimedEventsServiceImpl) Stop() {
@@ -47,6 +57,8 @@ func (s *TimedEventsServiceImpl) regenLoop() {
 		select {
 		case <-ticker.C:
 			s.processRegen()
+			s.processTriggers()
+			s.duelService.ProcessCountdowns(time.Now())
 		case <-s.stopChan:
 			return
 		}
@@ -71,6 +83,20 @@ func (s *TimedEventsServiceImpl) worldSaveLoop() {
 	}
 }
 
+func (s *TimedEventsServiceImpl) restockLoop() {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.commerceService.RestockMerchants()
+		case <-s.stopChan:
+			return
+		}
+	}
+}
+
 func (s *TimedEventsServiceImpl) processRegen() {
 	chars := s.userService.GetLoggedCharacters()
 	now := time.Now()
@@ -115,6 +141,7 @@ func (s *TimedEventsServiceImpl) processRegen() {
 						char.Mana = utils.Min(char.MaxMana, char.Mana+regen)
 						char.LastMeditationRegen = now
 						changed = true
+						s.trainingService.ImproveSkill(char, model.Meditate)
 					}
 				}
 			} else if now.Sub(char.LastManaRegen).Seconds() >= 2 {
@@ -137,17 +164,13 @@ func (s *TimedEventsServiceImpl) processRegen() {
 			changed = true
 		}
 
-		// Poison damage - Every 2 seconds (using LastHPRegen as a proxy or just hardcoded for now, ideally separate)
-		if char.Poisoned && now.Sub(char.LastHPRegen).Seconds() >= 2 {
-			// Actually HP regen and poison should probably have their own intervals.
-			// For simplicity let's assume they were tied to the 2s ticker.
-			damage := utils.RandomNumber(1, 5)
-			char.Hp -= damage
-			if char.Hp <= 0 {
-				s.messageService.HandleDeath(char, "Has muerto por el veneno.")
-			}
+		// Poison damage and effects expiration
+		if s.effectService.Tick(char, now) {
 			changed = true
 		}
+		if char.Dead {
+			continue
+		}
 
 		// Hunger and Thirst
 		if char.LastHungerUpdate.IsZero() {
@@ -186,45 +209,6 @@ func (s *TimedEventsServiceImpl) processRegen() {
 			}
 		}
 
-		// Paralysis / Immobilize Expiration
-		if (char.Paralyzed || char.Immobilized) && !char.ParalyzedSince.IsZero() {
-			if now.Sub(char.ParalyzedSince).Milliseconds() >= s.globalBalance.IntervalParalyzed {
-				char.Paralyzed = false
-				char.Immobilized = false
-				char.ParalyzedSince = time.Time{}
-				s.messageService.SendConsoleMessage(char, "¡Has recuperado el movimiento!", outgoing.INFO)
-				if conn := s.userService.GetConnection(char); conn != nil {
-					conn.Send(&outgoing.ParalyzeOkPacket{})
-				}
-			}
-		}
-
-		// Potion Effects Expiration
-		if !char.StrengthEffectEnd.IsZero() && now.After(char.StrengthEffectEnd) {
-			char.Attributes[model.Strength] = char.OriginalAttributes[model.Strength]
-			char.StrengthEffectEnd = time.Time{}
-			s.messageService.SendConsoleMessage(char, "El efecto de la poción de fuerza ha terminado.", outgoing.INFO)
-			conn := s.userService.GetConnection(char)
-			if conn != nil {
-				conn.Send(&outgoing.UpdateStrengthAndDexterityPacket{
-					Strength:  char.Attributes[model.Strength],
-					Dexterity: char.Attributes[model.Dexterity],
-				})
-			}
-		}
-		if !char.AgilityEffectEnd.IsZero() && now.After(char.AgilityEffectEnd) {
-			char.Attributes[model.Dexterity] = char.OriginalAttributes[model.Dexterity]
-			char.AgilityEffectEnd = time.Time{}
-			s.messageService.SendConsoleMessage(char, "El efecto de la poción de agilidad ha terminado.", outgoing.INFO)
-			conn := s.userService.GetConnection(char)
-			if conn != nil {
-				conn.Send(&outgoing.UpdateStrengthAndDexterityPacket{
-					Strength:  char.Attributes[model.Strength],
-					Dexterity: char.Attributes[model.Dexterity],
-				})
-			}
-		}
-
 		if changed {
 			conn := s.userService.GetConnection(char)
 			if conn != nil {
@@ -237,3 +221,119 @@ func (s *TimedEventsServiceImpl) processRegen() {
 		}
 	}
 }
+
+func (s *TimedEventsServiceImpl) processTriggers() {
+	now := time.Now()
+	for _, char := range s.userService.GetLoggedCharacters() {
+		s.processJail(char, now)
+		s.processAntiPicket(char, now)
+	}
+}
+
+// processAntiPicket keeps players from blocking city entrances: anyone standing on an
+// anti-picket tile is made visible, warned, and pushed away or jailed after a while.
+func (s *TimedEventsServiceImpl) processAntiPicket(char *model.Character, now time.Time) {
+	mapService := s.messageService.MapService()
+	if char.Privileges.IsGM() || !mapService.IsAntiPicket(char.Position) {
+		char.AntiPicketSince = time.Time{}
+		return
+	}
+
+	if char.Invisible || char.Hidden {
+		char.Invisible = false
+		char.Hidden = false
+		s.messageService.SendToArea(&outgoing.SetInvisiblePacket{CharIndex: char.CharIndex, Invisible: false}, char.Position)
+		s.messageService.SendConsoleMessage(char, "No puedes permanecer invisible en este lugar.", outgoing.INFO)
+	}
+
+	if char.AntiPicketSince.IsZero() {
+		char.AntiPicketSince = now
+		char.LastAntiPicketWarning = time.Time{}
+	}
+
+	if now.Sub(char.AntiPicketSince) >= time.Duration(s.globalBalance.AntiPicketLimit)*time.Second {
+		char.AntiPicketSince = time.Time{}
+		if s.globalBalance.AntiPicketJail {
+			slog.Info("Jailing user for blocking an anti-picket tile", "name", char.Name, "pos", char.Position)
+			s.jail(char, s.globalBalance.AntiPicketJailTime)
+		} else {
+			s.pushOffAntiPicket(char)
+		}
+		return
+	}
+
+	if now.Sub(char.LastAntiPicketWarning) >= time.Duration(s.globalBalance.AntiPicketWarnInterval)*time.Second {
+		char.LastAntiPicketWarning = now
+		if s.globalBalance.AntiPicketJail {
+			s.messageService.SendConsoleMessage(char, "¡¡¡Estás obstruyendo la vía pública, muévete o serás encarcelado!!!", outgoing.WARNING)
+		} else {
+			s.messageService.SendConsoleMessage(char, "¡¡¡Estás obstruyendo la vía pública, muévete o serás expulsado!!!", outgoing.WARNING)
+		}
+	}
+}
+
+func (s *TimedEventsServiceImpl) pushOffAntiPicket(char *model.Character) {
+	mapService := s.messageService.MapService()
+	for radius := 1; radius <= 5; radius++ {
+		for dx := -radius; dx <= radius; dx++ {
+			for dy := -radius; dy <= radius; dy++ {
+				x, y := int(char.Position.X)+dx, int(char.Position.Y)+dy
+				if !mapService.IsTileEmpty(char.Position.Map, x, y) {
+					continue
+				}
+				pos := model.Position{Map: char.Position.Map, X: byte(x), Y: byte(y)}
+				if mapService.IsAntiPicket(pos) {
+					continue
+				}
+				s.messageService.WarpCharacter(char, pos)
+				s.messageService.SendConsoleMessage(char, "Has sido expulsado por obstruir la vía pública.", outgoing.INFO)
+				return
+			}
+		}
+	}
+	slog.Warn("No free tile to push user off anti-picket tile", "name", char.Name, "pos", char.Position)
+}
+
+func (s *TimedEventsServiceImpl) jail(char *model.Character, minutes int) {
+	char.JailTime = int64(minutes)
+	char.LastJailTick = time.Now()
+	s.messageService.WarpCharacter(char, s.globalBalance.JailPosition)
+	s.messageService.SendConsoleMessage(char, fmt.Sprintf("Has sido encarcelado por %d minutos.", minutes), outgoing.INFO)
+}
+
+func (s *TimedEventsServiceImpl) inJail(pos model.Position) bool {
+	jail := s.globalBalance.JailPosition
+	dx, dy := int(pos.X)-int(jail.X), int(pos.Y)-int(jail.Y)
+	return pos.Map == jail.Map && max(dx, -dx, dy, -dy) <= s.globalBalance.JailRadius
+}
+
+func (s *TimedEventsServiceImpl) processJail(char *model.Character, now time.Time) {
+	if char.JailTime <= 0 {
+		return
+	}
+
+	// Walking out, portals and summons all end up back in the cell
+	if !s.inJail(char.Position) {
+		s.messageService.WarpCharacter(char, s.globalBalance.JailPosition)
+		s.messageService.SendConsoleMessage(char, "No puedes salir de la cárcel hasta cumplir tu condena.", outgoing.INFO)
+	}
+
+	// Start counting from login for players who were jailed in a previous session
+	if char.LastJailTick.IsZero() {
+		char.LastJailTick = now
+		return
+	}
+
+	if now.Sub(char.LastJailTick) < time.Minute {
+		return
+	}
+
+	char.JailTime--
+	char.LastJailTick = now
+
+	if char.JailTime <= 0 {
+		char.JailTime = 0
+		s.messageService.WarpCharacter(char, s.globalBalance.JailExit)
+		s.messageService.SendConsoleMessage(char, "Has cumplido tu condena.", outgoing.INFO)
+	}
+}
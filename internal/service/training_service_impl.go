@@ -3,6 +3,8 @@ package service
 import (
 	"fmt"
 	"math"
+	"math/rand"
+	"time"
 
 	"github.com/ao-go-server/internal/model"
 	"github.com/ao-go-server/internal/protocol/outgoing"
@@ -113,6 +115,43 @@ func (s *TrainingServiceImpl) CheckLevel(char *model.Character) {
 	}
 }
 
+// ImproveSkill rolls for a one point gain in skill after char used it successfully. The odds shrink
+// as the skill approaches MaxSkillPoints, and skills can't grow past what the character's level allows.
+func (s *TrainingServiceImpl) ImproveSkill(char *model.Character, skill model.Skill) {
+	if char.Dead || char.Skills == nil {
+		return
+	}
+
+	current := char.Skills[skill]
+	limit := utils.Min(model.MaxSkillPoints, int(char.Level)*s.globalBalance.SkillPointsPerLevel)
+	if current >= limit {
+		return
+	}
+
+	now := time.Now()
+	if now.Sub(char.LastSkillGain) < time.Duration(s.globalBalance.SkillGainInterval)*time.Second {
+		return
+	}
+
+	chance := s.globalBalance.SkillGainChance * float64(model.MaxSkillPoints-current) / model.MaxSkillPoints
+	chance = math.Max(chance, s.globalBalance.SkillMinGainChance)
+	if rand.Float64() >= chance {
+		return
+	}
+
+	char.Skills[skill] = current + 1
+	char.LastSkillGain = now
+
+	s.messageService.SendConsoleMessage(char, fmt.Sprintf("¡Has mejorado tu skill %s en un punto! Ahora tienes %d pts.", skill.Name(), current+1), outgoing.INFO)
+	if conn := s.userService.GetConnection(char); conn != nil {
+		conn.Send(&outgoing.SendSkillsPacket{
+			Archetype:   char.Archetype,
+			Skills:      char.Skills,
+			SkillPoints: char.SkillPoints,
+		})
+	}
+}
+
 func (s *TrainingServiceImpl) updateExpThreshold(char *model.Character) {
 	level := int(char.Level)
 	multiplier := 1.0
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"net"
 	"sync"
 	"github.com/ao-go-server/internal/protocol"
 	"github.com/ao-go-server/internal/model"
@@ -127,10 +128,20 @@ func (s *UserServiceImpl) KickByIP(ip string) int {
 	defer s.mu.RUnlock()
 	kicked := 0
 	for conn := range s.loggedUsers {
-		if conn.GetRemoteAddr() == ip {
+		if conn.GetRemoteAddr() == ip || RemoteIP(conn) == ip {
 			conn.Disconnect()
 			kicked++
 		}
 	}
 	return kicked
 }
+
+// RemoteIP returns the address a connection comes from, without the port.
+func RemoteIP(conn protocol.Connection) string {
+	addr := conn.GetRemoteAddr()
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
@@ -343,6 +343,50 @@ type ConfigItem struct {
 	Value       interface{} `json:"value"`
 }
 
+type SosTicket struct {
+	ID        int    `json:"id"`
+	Author    string `json:"author"`
+	Message   string `json:"message"`
+	Map       int    `json:"map"`
+	X         int    `json:"x"`
+	Y         int    `json:"y"`
+	ClaimedBy string `json:"claimed_by"`
+	Online    bool   `json:"online"`
+}
+
+type SosListMsg struct {
+	Tickets []SosTicket
+	Err     error
+}
+
+func fetchSosListCmd() tea.Cmd {
+	return func() tea.Msg {
+		resp, err := http.Get("http://localhost:7667/sos/list")
+		if err != nil {
+			return SosListMsg{Err: err}
+		}
+		defer resp.Body.Close()
+
+		var tickets []SosTicket
+		if err := json.NewDecoder(resp.Body).Decode(&tickets); err != nil {
+			return SosListMsg{Err: err}
+		}
+		return SosListMsg{Tickets: tickets}
+	}
+}
+
+func closeSosCmd(id int) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := http.PostForm("http://localhost:7667/sos/close", url.Values{"id": {strconv.Itoa(id)}})
+		if err != nil {
+			return ActionMsg{Err: err}
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return ActionMsg{Output: string(body)}
+	}
+}
+
 type ConfigListMsg struct {
 	Items []ConfigItem
 	Err   error
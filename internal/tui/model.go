@@ -77,6 +77,11 @@ type Model struct {
 
 	// Charts State
 	chartsData ChartsDataMsg
+
+	// Mod State
+	sosTickets []SosTicket
+	sosCursor  int
+	sosConfirm bool
 }
 
 func InitialModel() Model {
@@ -173,6 +178,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.activeTab == 6 { // Charts Tab
 			cmds = append(cmds, fetchChartsDataCmd())
 		}
+		if m.activeTab == 8 { // Mod Tab
+			cmds = append(cmds, fetchSosListCmd())
+		}
 	
 	case ServerStatusMsg:
 		m.serverStatus = msg.Status
@@ -246,6 +254,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	
 	case ChartsDataMsg:
 		m.chartsData = msg
+
+	case SosListMsg:
+		if msg.Err == nil {
+			m.sosTickets = msg.Tickets
+			if m.sosCursor >= len(m.sosTickets) {
+				m.sosCursor = 0
+			}
+		}
 	}
 
 	// Dispatch to active tab logic if needed (e.g. navigation)
@@ -265,6 +281,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m, cmd = m.updateConfig(msg)
 	case 6:
 		m, cmd = m.updateCharts(msg)
+	case 8:
+		m, cmd = m.updateMod(msg)
 	default:
 		// Other tabs not implemented yet
 	}
@@ -353,6 +371,8 @@ func (m Model) View() string {
 		content = m.viewConfig()
 	case 6:
 		content = m.viewCharts()
+	case 8:
+		content = m.viewMod()
 	default:
 		content = fmt.Sprintf("View for %s not implemented yet.", m.tabs[m.activeTab])
 	}
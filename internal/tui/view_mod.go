@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (m Model) updateMod(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.sosConfirm {
+			switch msg.String() {
+			case "y", "enter":
+				m.sosConfirm = false
+				if m.sosCursor < len(m.sosTickets) {
+					return m, tea.Batch(closeSosCmd(m.sosTickets[m.sosCursor].ID), fetchSosListCmd())
+				}
+			case "n", "esc":
+				m.sosConfirm = false
+			}
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "up", "k":
+			if m.sosCursor > 0 {
+				m.sosCursor--
+			}
+		case "down", "j":
+			if m.sosCursor < len(m.sosTickets)-1 {
+				m.sosCursor++
+			}
+		case "enter":
+			if len(m.sosTickets) > 0 {
+				m.sosConfirm = true
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m Model) viewMod() string {
+	var sb strings.Builder
+
+	sb.WriteString(titleStyle.Render("[SOS Queue]") + "\n")
+
+	if len(m.sosTickets) == 0 {
+		sb.WriteString("No open tickets.\n")
+		if m.lastActionMsg != "" {
+			sb.WriteString("\n" + titleStyle.Render("[Output]") + "\n" + m.lastActionMsg + "\n")
+		}
+		return detailStyle.Render(sb.String())
+	}
+
+	sb.WriteString(lipgloss.NewStyle().Foreground(subtle).Render(
+		fmt.Sprintf("  %-5s %-15s %-14s %-12s %s", "ID", "Player", "Position", "GM", "Message"),
+	) + "\n")
+
+	for i, t := range m.sosTickets {
+		cursor := " "
+		style := listItemStyle
+		if i == m.sosCursor {
+			cursor = "▶"
+			style = listSelectedStyle
+		}
+
+		author := t.Author
+		if !t.Online {
+			author += " (off)"
+		}
+		claimed := t.ClaimedBy
+		if claimed == "" {
+			claimed = "-"
+		}
+		msg := t.Message
+		if runes := []rune(msg); len(runes) > 50 {
+			msg = string(runes[:47]) + "..."
+		}
+
+		line := fmt.Sprintf("%s %-5d %-15s %-14s %-12s %s", cursor, t.ID, author, fmt.Sprintf("%d %d %d", t.Map, t.X, t.Y), claimed, msg)
+		sb.WriteString(style(line) + "\n")
+	}
+
+	sb.WriteString(lipgloss.NewStyle().Foreground(subtle).Render(
+		fmt.Sprintf("\nOpen tickets: %d (Enter to close the selected one)", len(m.sosTickets)),
+	))
+
+	if m.sosConfirm {
+		t := m.sosTickets[m.sosCursor]
+		sb.WriteString("\n\n" + titleStyle.Render(fmt.Sprintf("[Close ticket #%d from %s?]", t.ID, t.Author)) + "\n")
+		sb.WriteString(t.Message + "\n")
+		sb.WriteString(lipgloss.NewStyle().Foreground(subtle).Render("(y/Enter to confirm, n/Esc to cancel)"))
+	} else if m.lastActionMsg != "" {
+		sb.WriteString("\n\n" + titleStyle.Render("[Output]") + "\n" + m.lastActionMsg + "\n")
+	}
+
+	return detailStyle.Render(sb.String())
+}